@@ -30,6 +30,11 @@ func NewContext() context.Context {
 		log.Panicf("Error occurred while creating client id: %v", err)
 	}
 
+	return NewContextWithClientID(clientID)
+}
+
+// NewContextWithClientID creates a context for a known client id, letting separate processes act as the same client.
+func NewContextWithClientID(clientID string) context.Context {
 	return context.WithValue(context.Background(), contextPropertyKey{}, Context{
 		ClientID: clientID,
 	})
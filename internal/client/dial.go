@@ -0,0 +1,39 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+//nolint:gochecknoglobals,mnd // Configuration only kept at the time of first initialization.
+var KeepAliveClientParameters = keepalive.ClientParameters{
+	Time:    30 * time.Second,
+	Timeout: 10 * time.Second,
+}
+
+// Dial creates a gRPC client connection to the game server.
+func Dial(address string, enableTLS bool) (*grpc.ClientConn, error) {
+	var creds credentials.TransportCredentials
+
+	if enableTLS {
+		creds = credentials.NewClientTLSFromCert(nil, "")
+	} else {
+		creds = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.NewClient(
+		address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(KeepAliveClientParameters),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client for %s: %w", address, err)
+	}
+
+	return conn, nil
+}
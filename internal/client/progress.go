@@ -0,0 +1,177 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	progressDir      = "nukeship"
+	progressFile     = "campaign.json"
+	progressDirMode  = 0o755
+	progressFileMode = 0o644
+)
+
+var (
+	ErrMissionLocked = errors.New("mission is locked until the previous missions are completed")
+	ErrMissionNotWon = errors.New("mission was not won")
+	ErrNoReplay      = errors.New("replay of the game is unavailable")
+)
+
+// DefaultProgressPath returns where campaign progress is saved for the current user.
+func DefaultProgressPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate config directory: %w", err)
+	}
+
+	return filepath.Join(dir, progressDir, progressFile), nil
+}
+
+// LoadProgress reads the campaign progress saved at the path. A missing file means no mission has been completed.
+func LoadProgress(path string) (*pb.CampaignProgress, error) {
+	progress := &pb.CampaignProgress{Completed: []string{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return progress, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read campaign progress: %w", err)
+	}
+
+	err = protojson.Unmarshal(data, progress)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode campaign progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// SaveProgress writes the campaign progress to the path, creating its directory if needed.
+func SaveProgress(path string, progress *pb.CampaignProgress) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "    "}.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("could not encode campaign progress: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), progressDirMode)
+	if err != nil {
+		return fmt.Errorf("could not create campaign progress directory: %w", err)
+	}
+
+	err = os.WriteFile(path, data, progressFileMode)
+	if err != nil {
+		return fmt.Errorf("could not write campaign progress: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteMission records the mission as completed in the progress saved at the path, once the outcome of the game
+// shows that the client of the context won it while playing the mission, with the objective of the mission. The
+// outcome is taken from the replay of the game exported by the server, which is replayed again to verify it.
+func CompleteMission(
+	ctx context.Context,
+	gameClient pb.GameServiceClient,
+	path string,
+	mission *pb.Mission,
+	gameID string,
+) error {
+	replay, err := fetchReplay(ctx, gameClient, gameID)
+	if err != nil {
+		return err
+	}
+
+	err = game.VerifyReplay(replay)
+	if err != nil {
+		return fmt.Errorf("could not verify outcome of game %s: %w", gameID, err)
+	}
+
+	clientID := UnwrapContext(ctx).ClientID
+	objective := replay.GetGame().GetObjectives()[clientID]
+
+	// Several missions share an objective, so the game must also have been played for this very mission.
+	won := replay.GetWinner() == clientID && replay.GetGame().GetMission() == mission.GetId()
+	if !won || !proto.Equal(objective, mission.GetObjective()) {
+		return fmt.Errorf("%w: %s", ErrMissionNotWon, mission.GetId())
+	}
+
+	progress, err := LoadProgress(path)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(progress.GetCompleted(), mission.GetId()) {
+		return nil
+	}
+
+	progress.Completed = append(progress.Completed, mission.GetId())
+
+	return SaveProgress(path, progress)
+}
+
+// FindMission returns the mission of the campaign with the id, or nil.
+func FindMission(campaign *pb.Campaign, missionID string) *pb.Mission {
+	for _, mission := range campaign.GetMissions() {
+		if mission.GetId() == missionID {
+			return mission
+		}
+	}
+
+	return nil
+}
+
+// Unlocked reports whether the mission may be played, which requires every earlier mission of the campaign to be
+// completed.
+func Unlocked(campaign *pb.Campaign, progress *pb.CampaignProgress, missionID string) bool {
+	for _, mission := range campaign.GetMissions() {
+		if mission.GetId() == missionID {
+			return true
+		}
+
+		if !slices.Contains(progress.GetCompleted(), mission.GetId()) {
+			return false
+		}
+	}
+
+	return false
+}
+
+func fetchReplay(
+	ctx context.Context,
+	gameClient pb.GameServiceClient,
+	gameID string,
+) (*pb.ReplayHeader, error) {
+	unaryCtx, cancel := NewUnaryContext(ctx)
+	defer cancel()
+
+	resp, err := gameClient.ExportReplay(unaryCtx, &pb.ExportReplayRequest{GameId: gameID})
+	if err != nil {
+		return nil, fmt.Errorf("could not export replay of game %s: %w", gameID, err)
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoReplay, resp.GetStatus().String())
+	}
+
+	replay, err := game.ReadReplay(bytes.NewReader(resp.GetReplay()))
+	if err != nil {
+		return nil, fmt.Errorf("could not read replay of game %s: %w", gameID, err)
+	}
+
+	return replay, nil
+}
@@ -0,0 +1,362 @@
+package game
+
+import (
+	"errors"
+
+	"github.com/passeriform/internal/pb"
+)
+
+var ErrAbilityOnCooldown = errors.New("ability is on cooldown")
+
+type (
+	// targetRule validates the target of an action against the current game state.
+	targetRule func(g *Game, playerID string, action *pb.Action) error
+
+	// outcome collects what an ability did. Node changes are announced along with the resolved action, while private
+	// events are only delivered to the caster and targeted events only to the targeted player once it has resolved.
+	outcome struct {
+		changes  []*pb.NodeChange
+		private  []pb.GameEventPayload
+		targeted []pb.GameEventPayload
+	}
+
+	// ability describes an action players can use. The cost is deducted from the budget of the caster when the
+	// action is submitted, after which the ability cannot be used again for the cooldown in turns of the caster.
+	// Covert abilities are only announced to the caster, unless the caster gets caught using black-market tools.
+	ability struct {
+		target   targetRule
+		effect   func(g *Game, playerID string, action *pb.Action) outcome
+		market   pb.Market
+		cost     int32
+		cooldown int32
+		covert   bool
+	}
+)
+
+//nolint:exhaustive,gochecknoglobals // Ability registry is static. EndTurn is handled by the turn cycle instead.
+var abilities = map[pb.ActionType]ability{
+	pb.ActionType_Strike: {
+		target:   targetVisibleNode,
+		effect:   applyStrike,
+		market:   pb.Market_Corporate,
+		cost:     StrikeCost,
+		cooldown: 0,
+		covert:   false,
+	},
+	pb.ActionType_Sonar: {
+		target:   targetOpponent,
+		effect:   applySonar,
+		market:   pb.Market_Corporate,
+		cost:     SonarCost,
+		cooldown: SonarCooldown,
+		covert:   false,
+	},
+	pb.ActionType_Nuke: {
+		target:   targetVisibleNode,
+		effect:   applyNuke,
+		market:   pb.Market_Corporate,
+		cost:     NukeCost,
+		cooldown: NukeCooldown,
+		covert:   false,
+	},
+	pb.ActionType_Infest: {
+		target:   targetVisibleNode,
+		effect:   applyInfest,
+		market:   pb.Market_BlackMarket,
+		cost:     InfestCost,
+		cooldown: InfestCooldown,
+		covert:   true,
+	},
+	pb.ActionType_Treat: {
+		target:   targetOwnNode,
+		effect:   applyTreat,
+		market:   pb.Market_Corporate,
+		cost:     TreatCost,
+		cooldown: 0,
+		covert:   false,
+	},
+	pb.ActionType_Bleeder: {
+		target:   targetVisibleNode,
+		effect:   applyBleeder,
+		market:   pb.Market_Corporate,
+		cost:     BleederCost,
+		cooldown: BleederCooldown,
+		covert:   false,
+	},
+	pb.ActionType_DDoS: {
+		target:   targetDDoS,
+		effect:   applyDDoS,
+		market:   pb.Market_Corporate,
+		cost:     DDoSCost,
+		cooldown: DDoSCooldown,
+		covert:   false,
+	},
+	pb.ActionType_Botnet: {
+		target:   targetCluster,
+		effect:   applyBotnet,
+		market:   pb.Market_Corporate,
+		cost:     BotnetCost,
+		cooldown: BotnetCooldown,
+		covert:   false,
+	},
+	pb.ActionType_Phisher: {
+		target:   targetOwnNode,
+		effect:   applyPhisher,
+		market:   pb.Market_BlackMarket,
+		cost:     PhisherCost,
+		cooldown: PhisherCooldown,
+		covert:   true,
+	},
+	pb.ActionType_Backdoor: {
+		target:   targetVisibleNode,
+		effect:   applyBackdoor,
+		market:   pb.Market_BlackMarket,
+		cost:     BackdoorCost,
+		cooldown: BackdoorCooldown,
+		covert:   true,
+	},
+	pb.ActionType_Siphon: {
+		target:   targetSiphon,
+		effect:   applySiphon,
+		market:   pb.Market_Corporate,
+		cost:     SiphonCost,
+		cooldown: SiphonCooldown,
+		covert:   false,
+	},
+	pb.ActionType_Ventriloquist: {
+		target:   targetFramedPlayer,
+		effect:   applyVentriloquist,
+		market:   pb.Market_BlackMarket,
+		cost:     VentriloquistCost,
+		cooldown: VentriloquistCooldown,
+		covert:   true,
+	},
+	pb.ActionType_Sniper: {
+		target:   targetOpponent,
+		effect:   applySniper,
+		market:   pb.Market_Corporate,
+		cost:     SniperCost,
+		cooldown: SniperCooldown,
+		covert:   false,
+	},
+	pb.ActionType_HealthScan: {
+		target:   targetOwnNode,
+		effect:   applyHealthScan,
+		market:   pb.Market_Corporate,
+		cost:     HealthScanCost,
+		cooldown: HealthScanCooldown,
+		covert:   false,
+	},
+	pb.ActionType_DeepScan: {
+		target:   targetScanPath,
+		effect:   applyDeepScan,
+		market:   pb.Market_Corporate,
+		cost:     DeepScanCost,
+		cooldown: DeepScanCooldown,
+		covert:   false,
+	},
+	pb.ActionType_SelfAmputation: {
+		target:   targetAmputation,
+		effect:   applySelfAmputation,
+		market:   pb.Market_Corporate,
+		cost:     SelfAmputationCost,
+		cooldown: SelfAmputationCooldown,
+		covert:   false,
+	},
+	pb.ActionType_ToggleUpkeep: {
+		target:   targetOwnNode,
+		effect:   applyToggleUpkeep,
+		market:   pb.Market_Corporate,
+		cost:     ToggleUpkeepCost,
+		cooldown: 0,
+		covert:   false,
+	},
+}
+
+// spend validates the action against the current game state, deducts its cost at the price of the player's faction
+// from the player's budget and starts its cooldown.
+func (g *Game) spend(player *Player, action *pb.Action) error {
+	spec, ok := abilities[action.GetType()]
+	if !ok {
+		return ErrInvalidAction
+	}
+
+	if player.Cooldowns[action.GetType().String()] > 0 {
+		return ErrAbilityOnCooldown
+	}
+
+	if player.locked(action.GetType()) {
+		return ErrAbilityLocked
+	}
+
+	err := spec.target(g, player.ID, action)
+	if err != nil {
+		return err
+	}
+
+	cost := g.price(player, action.GetType())
+	if player.Budget < cost {
+		return ErrInsufficientPower
+	}
+
+	player.Budget -= cost
+
+	if spec.cooldown > 0 {
+		player.Cooldowns[action.GetType().String()] = spec.cooldown
+	}
+
+	return nil
+}
+
+// cost returns the list price of the ability in the game. Simulations may override the price from the registry.
+func (g *Game) cost(actionType pb.ActionType) int32 {
+	cost, ok := g.costs[actionType]
+	if !ok {
+		cost = abilities[actionType].cost
+	}
+
+	return cost
+}
+
+// price returns what the player pays for the ability, at the price of the player's faction.
+func (g *Game) price(player *Player, actionType pb.ActionType) int32 {
+	return player.price(g.cost(actionType), abilities[actionType].market)
+}
+
+// prices returns what the player pays for every ability, by the name of the ability.
+func (g *Game) prices(player *Player) map[string]int32 {
+	prices := make(map[string]int32, len(abilities))
+
+	for actionType := range abilities {
+		prices[actionType.String()] = g.price(player, actionType)
+	}
+
+	return prices
+}
+
+// retarget validates the target of a queued action again right before it resolves. Actions whose target is no longer
+// valid are dropped, and the player is refunded their cost and cooldown.
+func (g *Game) retarget(playerID string, action *pb.Action) bool {
+	spec := abilities[action.GetType()]
+
+	if spec.target(g, playerID, action) == nil {
+		return true
+	}
+
+	player := g.players[playerID]
+	player.Budget += player.price(g.cost(action.GetType()), spec.market)
+	delete(player.Cooldowns, action.GetType().String())
+
+	return false
+}
+
+func (g *Game) resolve(playerID string, action *pb.Action) {
+	spec := abilities[action.GetType()]
+	result := spec.effect(g, playerID, action)
+
+	resolved := &pb.GameEvent_ActionResolved{
+		ActionResolved: &pb.ActionResolved{
+			Player:  playerID,
+			Action:  action,
+			Changes: result.changes,
+		},
+	}
+
+	filters := []eventFilter{}
+
+	if framed, ok := g.frames[playerID]; ok {
+		delete(g.frames, playerID)
+
+		filters = append(filters, framed.filter(playerID))
+	}
+
+	if spec.covert && !g.caught(g.players[playerID], spec.market, 0) {
+		g.send(playerID, resolved)
+	} else {
+		g.broadcast(resolved, filters...)
+	}
+
+	for _, payload := range result.private {
+		g.send(playerID, payload)
+	}
+
+	for _, payload := range result.targeted {
+		g.send(action.GetTargetPlayer(), payload)
+	}
+}
+
+// cool advances the cooldowns of the player by one of their turns.
+func (player *Player) cool() {
+	for actionType, remaining := range player.Cooldowns {
+		if remaining <= 1 {
+			delete(player.Cooldowns, actionType)
+			continue
+		}
+
+		player.Cooldowns[actionType] = remaining - 1
+	}
+}
+
+// opponent resolves the targeted player of the action, which must be another standing player.
+func (g *Game) opponent(playerID string, action *pb.Action) (*Player, error) {
+	opponent, ok := g.players[action.GetTargetPlayer()]
+	if !ok || opponent.ID == playerID || opponent.Eliminated {
+		return nil, ErrInvalidAction
+	}
+
+	return opponent, nil
+}
+
+// opponentNode resolves the targeted node of the action, which must be a standing node of another standing player.
+func (g *Game) opponentNode(playerID string, action *pb.Action) (*pb.FsTreeNode, error) {
+	opponent, err := g.opponent(playerID, action)
+	if err != nil {
+		return nil, err
+	}
+
+	node := opponent.Tree.GetTop().Find(action.GetTargetNode())
+	if node == nil || node.GetDestroyed() {
+		return nil, ErrInvalidAction
+	}
+
+	return node, nil
+}
+
+// targetOpponent accepts any standing opponent. The targeted node is ignored.
+func targetOpponent(g *Game, playerID string, action *pb.Action) error {
+	_, err := g.opponent(playerID, action)
+	return err
+}
+
+// targetVisibleNode accepts standing nodes of opponents that are not obscured.
+func targetVisibleNode(g *Game, playerID string, action *pb.Action) error {
+	node, err := g.opponentNode(playerID, action)
+	if err != nil {
+		return err
+	}
+
+	if node.GetVisibility() == pb.Visibility_Obscured {
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// targetOwnNode accepts standing nodes of the caster.
+func targetOwnNode(g *Game, playerID string, action *pb.Action) error {
+	if action.GetTargetPlayer() != playerID {
+		return ErrInvalidAction
+	}
+
+	return g.ownNode(playerID, action.GetTargetNode())
+}
+
+// ownNode checks that the node is a standing node of the player.
+func (g *Game) ownNode(playerID string, id int32) error {
+	node := g.players[playerID].Tree.GetTop().Find(id)
+	if node == nil || node.GetDestroyed() {
+		return ErrInvalidAction
+	}
+
+	return nil
+}
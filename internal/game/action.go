@@ -0,0 +1,43 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	StrikeCost   = 10
+	StrikeDamage = 15
+)
+
+func applyStrike(g *Game, _ string, action *pb.Action) outcome {
+	owner := action.GetTargetPlayer()
+	changes := []*pb.NodeChange{}
+
+	node := g.players[owner].Tree.GetTop().Find(action.GetTargetNode())
+	if node != nil {
+		changes = append(changes, damageNode(owner, node, StrikeDamage))
+	}
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
+
+// damageNode drains the shield of the node before its power. A node without power is destroyed and fully revealed.
+func damageNode(owner string, node *pb.FsTreeNode, amount int32) *pb.NodeChange {
+	shieldDamage := min(node.GetShield(), amount)
+	powerDamage := min(node.GetPower(), amount-shieldDamage)
+
+	node.Shield -= shieldDamage
+	node.Power -= powerDamage
+
+	if node.GetPower() == 0 && !node.GetDestroyed() {
+		node.Destroyed, node.Visibility = true, pb.Visibility_VisibleSentinel
+	}
+
+	return &pb.NodeChange{
+		Owner:       owner,
+		Node:        node.GetId(),
+		ShieldDelta: -shieldDamage,
+		PowerDelta:  -powerDamage,
+		Destroyed:   node.GetDestroyed(),
+	}
+}
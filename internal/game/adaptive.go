@@ -0,0 +1,260 @@
+package game
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	// AdaptiveFaction is the faction adaptive bots play as, getting the alliance discounts of corporations.
+	AdaptiveFaction = pb.Faction_CEO
+
+	// DefaultAdaptationRate is the share of the memory of an adaptive bot that is taken over by every play it sees.
+	DefaultAdaptationRate = 0.25
+
+	// adaptedThreshold is the weight a threat, or the pressure on a node, has to reach before an adaptive bot counters
+	// it.
+	adaptedThreshold = 0.5
+)
+
+type (
+	// threat groups the abilities of opponents by the counter an adaptive bot answers them with.
+	threat int
+
+	// Adaptive is a corporate bot that adapts to the plays of its opponents. It remembers which threats they used
+	// recently and which of its nodes they aimed at, weighing recent plays by its adaptation rate, and counters
+	// threats once they weigh enough: damage by shielding the nodes under pressure, infections by treating them and
+	// scans by masking its sentinels. A threat is only forgotten once its counter went through. Otherwise it plays
+	// like a medium bot.
+	Adaptive struct {
+		fallback Strategy
+		threats  map[threat]float64
+		pressure map[int32]float64
+		factions map[string]pb.Faction
+		id       string
+		rate     float64
+	}
+)
+
+const (
+	threatDamage threat = iota
+	threatInfection
+	threatScan
+)
+
+//nolint:exhaustive,gochecknoglobals // Abilities without a threat to the bot are not countered.
+var threats = map[pb.ActionType]threat{
+	pb.ActionType_Strike:   threatDamage,
+	pb.ActionType_Nuke:     threatDamage,
+	pb.ActionType_Bleeder:  threatDamage,
+	pb.ActionType_DDoS:     threatDamage,
+	pb.ActionType_Botnet:   threatDamage,
+	pb.ActionType_Siphon:   threatDamage,
+	pb.ActionType_Sniper:   threatDamage,
+	pb.ActionType_Infest:   threatInfection,
+	pb.ActionType_Backdoor: threatInfection,
+	pb.ActionType_Sonar:    threatScan,
+	pb.ActionType_DeepScan: threatScan,
+}
+
+// counterAbilities are the abilities an adaptive bot answers threats with. Damage is countered in the shop instead.
+//
+//nolint:gochecknoglobals // Counter registry is static.
+var counterAbilities = map[threat]pb.ActionType{
+	threatInfection: pb.ActionType_Treat,
+	threatScan:      pb.ActionType_Phisher,
+}
+
+// NewAdaptive creates an adaptive bot playing as the player. Rates outside of (0, 1] fall back to the default.
+func NewAdaptive(playerID string, rate float64, seed uint64) *Adaptive {
+	if rate <= 0 || rate > 1 {
+		rate = DefaultAdaptationRate
+	}
+
+	return &Adaptive{
+		fallback: NewStrategy(pb.BotDifficulty_Medium, seed),
+		threats:  map[threat]float64{},
+		pressure: map[int32]float64{},
+		factions: map[string]pb.Faction{},
+		id:       playerID,
+		rate:     rate,
+	}
+}
+
+// Observe records the plays of opponents in the event. Corporations adapt to every faction at its own pace, so the
+// rate is scaled by the adaptation of the faction of the opponent.
+func (bot *Adaptive) Observe(event *pb.GameEvent) {
+	switch payload := event.GetPayload().(type) {
+	case *pb.GameEvent_ActionResolved:
+		resolved := payload.ActionResolved
+		action := resolved.GetAction()
+
+		if resolved.GetPlayer() == bot.id {
+			bot.countered(action.GetType())
+			return
+		}
+
+		kind, ok := threats[action.GetType()]
+		if !ok {
+			return
+		}
+
+		nodes := []int32{}
+
+		if action.GetTargetPlayer() == bot.id && kind != threatScan {
+			nodes = append(nodes, action.GetTargetNode())
+			nodes = append(nodes, action.GetTargetNodes()...)
+		}
+
+		bot.adapt(resolved.GetPlayer(), kind, nodes)
+	case *pb.GameEvent_Purchased:
+		purchased := payload.Purchased
+		purchase := purchased.GetPurchase()
+
+		if purchased.GetPlayer() == bot.id && purchase.GetUpgrade() == pb.UpgradeType_ShieldBoost {
+			bot.threats[threatDamage] = 0
+			delete(bot.pressure, purchase.GetNode())
+		}
+	case *pb.GameEvent_Alert:
+		bot.adapt("", threatInfection, nil)
+	case *pb.GameEvent_Prompt:
+		if payload.Prompt.GetType() == pb.PromptType_BackdoorDetected {
+			bot.adapt("", threatInfection, payload.Prompt.GetNodes())
+		}
+	}
+}
+
+// adapt fades the memory of the bot and weighs in the threat and the nodes it was aimed at. Threats of unknown
+// players are adapted to at the default rate.
+func (bot *Adaptive) adapt(playerID string, kind threat, nodes []int32) {
+	adaptation := int32(DefaultAdaptation)
+	if faction, ok := bot.factions[playerID]; ok {
+		adaptation = Adaptation(faction)
+	}
+
+	rate := min(bot.rate*float64(adaptation)/percent, 1)
+
+	for key := range bot.threats {
+		bot.threats[key] *= 1 - rate
+	}
+
+	bot.threats[kind] += rate
+
+	if len(nodes) == 0 {
+		return
+	}
+
+	for node := range bot.pressure {
+		bot.pressure[node] *= 1 - rate
+	}
+
+	for _, node := range nodes {
+		bot.pressure[node] += rate / float64(len(nodes))
+	}
+}
+
+// countered forgets the threat the ability counters, once the bot has used it.
+func (bot *Adaptive) countered(ability pb.ActionType) {
+	for kind, counter := range counterAbilities {
+		if counter == ability {
+			bot.threats[kind] = 0
+		}
+	}
+}
+
+// Counter returns a purchase shielding the node under the most pressure once damage has become a threat, or nil.
+func (bot *Adaptive) Counter(board *pb.Board) *pb.Purchase {
+	bot.factions = board.GetFactions()
+
+	if bot.threats[threatDamage] < adaptedThreshold {
+		return nil
+	}
+
+	node := bot.pressured(board, false)
+	if node == nil || bot.pressure[node.GetId()] < adaptedThreshold {
+		return nil
+	}
+
+	list := upgrades[pb.UpgradeType_ShieldBoost].prices[pb.Market_Corporate]
+	if board.GetBudget() < factionPrice(bot.factions[bot.id], list, pb.Market_Corporate) {
+		return nil
+	}
+
+	return &pb.Purchase{
+		Upgrade: pb.UpgradeType_ShieldBoost,
+		Market:  pb.Market_Corporate,
+		Node:    node.GetId(),
+	}
+}
+
+// Act picks the counter-ability of the heaviest threat that has been adapted to, or falls back to a medium strike.
+func (bot *Adaptive) Act(board *pb.Board) *pb.Action {
+	bot.factions = board.GetFactions()
+
+	kinds := slices.SortedFunc(maps.Keys(counterAbilities), func(a, b threat) int {
+		return cmp.Compare(bot.threats[b], bot.threats[a])
+	})
+
+	for _, kind := range kinds {
+		ability := counterAbilities[kind]
+
+		if bot.threats[kind] < adaptedThreshold ||
+			board.GetBudget() < board.GetCosts()[ability.String()] ||
+			board.GetCooldowns()[ability.String()] > 0 {
+			continue
+		}
+
+		// Scans are countered by masking the sentinels they are looking for, or by planting a decoy without any.
+		node := bot.pressured(board, kind == threatScan)
+		if node == nil {
+			continue
+		}
+
+		return &pb.Action{
+			Type:         ability,
+			TargetPlayer: bot.id,
+			TargetNode:   node.GetId(),
+			TargetNodes:  nil,
+			Disguise:     nil,
+			OwnNode:      0,
+			FramedPlayer: "",
+			TargetPath:   nil,
+		}
+	}
+
+	return bot.fallback(board)
+}
+
+// pressured returns the standing node of the bot under the most pressure, preferring sentinels when asked to and
+// falling back to the top node when no node has been aimed at.
+func (bot *Adaptive) pressured(board *pb.Board, sentinel bool) *pb.FsTreeNode {
+	candidates, sentinels := []*pb.FsTreeNode{}, []*pb.FsTreeNode{}
+
+	board.GetOwn().GetTop().Walk(func(node *pb.FsTreeNode) {
+		if node.GetDestroyed() {
+			return
+		}
+
+		candidates = append(candidates, node)
+
+		if node.GetSentinel() {
+			sentinels = append(sentinels, node)
+		}
+	})
+
+	if sentinel && len(sentinels) > 0 {
+		candidates = sentinels
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Ties go to the first node in pre-order, starting with the top.
+	return slices.MaxFunc(candidates, func(a, b *pb.FsTreeNode) int {
+		return cmp.Compare(bot.pressure[a.GetId()], bot.pressure[b.GetId()])
+	})
+}
@@ -0,0 +1,109 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestAdaptiveCountersDamageUntilShielded(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	bot := NewAdaptive(bob, 1, fixtureSeed)
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Strike, bob, 3))
+	fix.game.tick()
+
+	seen := fix.observe(bot, 0)
+
+	counter := bot.Counter(fix.board(t, bob))
+	if counter.GetUpgrade() != pb.UpgradeType_ShieldBoost || counter.GetNode() != 3 {
+		t.Fatalf("Got counter %v, want a shield boost on the struck node 3", counter)
+	}
+
+	err := fix.game.Purchase(bob, counter)
+	if err != nil {
+		t.Fatalf("Could not buy the counter: %v", err)
+	}
+
+	fix.observe(bot, seen)
+
+	if counter = bot.Counter(fix.board(t, bob)); counter != nil {
+		t.Errorf("Got counter %v once shielded, want none", counter)
+	}
+}
+
+func TestAdaptiveMasksSentinelsAgainstScans(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	bot := NewAdaptive(bob, 1, fixtureSeed)
+
+	fix.submit(t, alice, &pb.Action{
+		Type:         pb.ActionType_Sonar,
+		TargetPlayer: bob,
+		TargetNode:   0,
+		TargetNodes:  nil,
+		Disguise:     nil,
+		OwnNode:      0,
+		FramedPlayer: "",
+		TargetPath:   nil,
+	})
+	fix.game.tick()
+	fix.observe(bot, 0)
+
+	// No node was aimed at, so the first sentinel in pre-order is masked.
+	action := bot.Act(fix.board(t, bob))
+	if action.GetType() != pb.ActionType_Phisher || action.GetTargetNode() != 2 {
+		t.Errorf(
+			"Got %s on node %d, want %s on sentinel 2",
+			action.GetType(),
+			action.GetTargetNode(),
+			pb.ActionType_Phisher,
+		)
+	}
+}
+
+func TestAdaptiveActPaysEffectiveCosts(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	bot := NewAdaptive(bob, 1, fixtureSeed)
+	bot.threats[threatInfection], bot.pressure[1] = 1, 1
+
+	action := bot.Act(fix.board(t, bob))
+	if got := action.GetType(); got != pb.ActionType_Treat || action.GetTargetNode() != 1 {
+		t.Fatalf("Got %s on node %d, want Treat on node 1", got, action.GetTargetNode())
+	}
+
+	fix.game.costs[pb.ActionType_Treat] = fixtureBudget + 1
+
+	if action = bot.Act(fix.board(t, bob)); action.GetType() == pb.ActionType_Treat {
+		t.Errorf("Got %s beyond the budget, want the bot to fall back", action.GetType())
+	}
+}
+
+// observe hands the events delivered to the player of the bot from the given index onwards to the bot, and returns
+// the index the next call should start from.
+func (fix *fixture) observe(bot *Adaptive, from int) int {
+	events := fix.events(bot.id)
+
+	for _, event := range events[from:] {
+		bot.Observe(event)
+	}
+
+	return len(events)
+}
+
+// board returns the board of the player.
+func (fix *fixture) board(t *testing.T, playerID string) *pb.Board {
+	t.Helper()
+
+	board, err := fix.game.Board(playerID)
+	if err != nil {
+		t.Fatalf("Could not get the board of %s: %v", playerID, err)
+	}
+
+	return board
+}
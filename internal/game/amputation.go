@@ -0,0 +1,68 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	SelfAmputationCost     = 5
+	SelfAmputationCooldown = 2
+)
+
+// targetAmputation accepts standing nodes of the caster below the top, as long as some standing sentinel is left
+// outside of their subtree.
+func targetAmputation(g *Game, playerID string, action *pb.Action) error {
+	err := targetOwnNode(g, playerID, action)
+	if err != nil {
+		return err
+	}
+
+	top := g.players[playerID].Tree.GetTop()
+	if action.GetTargetNode() == top.GetId() {
+		return ErrInvalidAction
+	}
+
+	standing := standingSentinels(top)
+	if standing > 0 && standingSentinels(top.Find(action.GetTargetNode())) == standing {
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+func standingSentinels(node *pb.FsTreeNode) int {
+	standing := 0
+
+	node.Walk(func(visited *pb.FsTreeNode) {
+		if visited.GetSentinel() && !visited.GetDestroyed() {
+			standing++
+		}
+	})
+
+	return standing
+}
+
+// applySelfAmputation permanently cuts the subtree of the targeted node off the tree of the caster. Its power and
+// sentinels are lost, and every effect on its nodes is cleared, which stops infestations from spreading through it.
+func applySelfAmputation(g *Game, playerID string, action *pb.Action) outcome {
+	player := g.players[playerID]
+	changes := []*pb.NodeChange{}
+
+	player.Tree.GetTop().Detach(action.GetTargetNode()).Walk(func(node *pb.FsTreeNode) {
+		player.clearEffects(node.GetId())
+
+		if node.GetDestroyed() {
+			return
+		}
+
+		changes = append(changes, &pb.NodeChange{
+			Owner:       playerID,
+			Node:        node.GetId(),
+			ShieldDelta: -node.GetShield(),
+			PowerDelta:  -node.GetPower(),
+			Destroyed:   true,
+		})
+	})
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestTickDropsActionsOnAmputatedNodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action *pb.Action
+		name   string
+		caster string
+	}{
+		{name: "strike", caster: alice, action: nodeAction(pb.ActionType_Strike, bob, 2)},
+		{name: "deep scan", caster: alice, action: nodeAction(pb.ActionType_DeepScan, bob, 2)},
+		{name: "backdoor", caster: alice, action: nodeAction(pb.ActionType_Backdoor, bob, 2)},
+		{name: "phisher", caster: bob, action: nodeAction(pb.ActionType_Phisher, bob, 2)},
+		{name: "upkeep", caster: bob, action: nodeAction(pb.ActionType_ToggleUpkeep, bob, 2)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The control game runs the same tick without the dropped action, so the budgets of both games only match
+			// if its cost was refunded in full.
+			fix, control := newFixture(t, pb.GameMode_RealTime), newFixture(t, pb.GameMode_RealTime)
+
+			for _, played := range []*fixture{fix, control} {
+				played.game.players[test.caster].unlocked[pb.ActionType_Backdoor] = true
+				played.submit(t, bob, nodeAction(pb.ActionType_SelfAmputation, bob, 1))
+			}
+
+			fix.submit(t, test.caster, test.action)
+
+			fix.game.tick()
+			control.game.tick()
+
+			for _, resolved := range fix.resolved(test.caster) {
+				if resolved.GetAction() == test.action {
+					t.Fatalf("Action on amputated node resolved: %v", resolved)
+				}
+			}
+
+			caster := fix.game.players[test.caster]
+
+			if _, ok := caster.Cooldowns[test.action.GetType().String()]; ok {
+				t.Errorf("Got cooldowns %v, want none for the dropped action", caster.Cooldowns)
+			}
+
+			if want := control.game.players[test.caster].Budget; caster.Budget != want {
+				t.Errorf("Got budget %d, want %d with the action refunded", caster.Budget, want)
+			}
+		})
+	}
+}
+
+func TestSelfAmputationTargeting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prepare func(top *pb.FsTreeNode)
+		action  *pb.Action
+		name    string
+		valid   bool
+	}{
+		{
+			name:   "node holding a sentinel while another stands",
+			action: nodeAction(pb.ActionType_SelfAmputation, bob, 1),
+			valid:  true,
+		},
+		{
+			name:   "sentinel while another stands",
+			action: nodeAction(pb.ActionType_SelfAmputation, bob, 3),
+			valid:  true,
+		},
+		{
+			name:   "top",
+			action: nodeAction(pb.ActionType_SelfAmputation, bob, 0),
+		},
+		{
+			name:   "node of an opponent",
+			action: nodeAction(pb.ActionType_SelfAmputation, alice, 4),
+		},
+		{
+			name:   "unknown node",
+			action: nodeAction(pb.ActionType_SelfAmputation, bob, 42),
+		},
+		{
+			name:    "destroyed node",
+			action:  nodeAction(pb.ActionType_SelfAmputation, bob, 4),
+			prepare: func(top *pb.FsTreeNode) { top.Find(4).Destroyed = true },
+		},
+		{
+			name:    "node holding the last standing sentinel",
+			action:  nodeAction(pb.ActionType_SelfAmputation, bob, 1),
+			prepare: func(top *pb.FsTreeNode) { top.Find(3).Destroyed = true },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_RealTime)
+
+			if test.prepare != nil {
+				test.prepare(fix.game.players[bob].Tree.GetTop())
+			}
+
+			err := fix.game.Submit(bob, test.action)
+			if valid := err == nil; valid != test.valid {
+				t.Errorf("Got amputation accepted %t (%v), want %t", valid, err, test.valid)
+			}
+		})
+	}
+}
+
+func TestSelfAmputationDetachesSubtree(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+
+	fix.submit(t, bob, nodeAction(pb.ActionType_SelfAmputation, bob, 1))
+	fix.game.tick()
+
+	top := fix.game.players[bob].Tree.GetTop()
+
+	for _, id := range []int32{1, 2} {
+		if top.Find(id) != nil {
+			t.Errorf("Got node %d in the tree, want it amputated", id)
+		}
+	}
+
+	if got := top.GetChildrenCount(); got != 2 {
+		t.Errorf("Got %d children on the top, want 2", got)
+	}
+
+	if got := top.GetNestedCount(); got != 2 {
+		t.Errorf("Got %d nested nodes under the top, want 2", got)
+	}
+
+	var destroyed []int32
+
+	for _, resolved := range fix.resolved(bob) {
+		for _, change := range resolved.GetChanges() {
+			if change.GetDestroyed() {
+				destroyed = append(destroyed, change.GetNode())
+			}
+		}
+	}
+
+	if want := []int32{1, 2}; !slices.Equal(destroyed, want) {
+		t.Errorf("Got nodes %v reported destroyed, want %v", destroyed, want)
+	}
+}
+
+func TestDetachUpdatesAncestorCounts(t *testing.T) {
+	t.Parallel()
+
+	top := newFixture(t, pb.GameMode_Turns).game.players[bob].Tree.GetTop()
+
+	if top.Detach(0) != nil || top.Detach(42) != nil {
+		t.Fatal("Got a subtree detached for the top or an unknown node, want none")
+	}
+
+	detached := top.Detach(2)
+	if detached.GetId() != 2 {
+		t.Fatalf("Got node %d detached, want 2", detached.GetId())
+	}
+
+	counts := []struct {
+		node             int32
+		children, nested int32
+	}{
+		{node: 0, children: 3, nested: 3},
+		{node: 1, children: 0, nested: 0},
+	}
+
+	for _, want := range counts {
+		node := top.Find(want.node)
+		if node.GetChildrenCount() != want.children || node.GetNestedCount() != want.nested {
+			t.Errorf(
+				"Got %d children and %d nested nodes on node %d, want %d and %d",
+				node.GetChildrenCount(),
+				node.GetNestedCount(),
+				want.node,
+				want.children,
+				want.nested,
+			)
+		}
+	}
+
+	top.Detach(1)
+
+	if top.GetChildrenCount() != 2 || top.GetNestedCount() != 2 {
+		t.Errorf(
+			"Got %d children and %d nested nodes on the top, want 2 and 2",
+			top.GetChildrenCount(),
+			top.GetNestedCount(),
+		)
+	}
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	BackdoorCost     = 30
+	BackdoorCooldown = 5
+	// BackdoorSiphonPercent is the share of the income of the infected area a backdoor diverts to its installer.
+	BackdoorSiphonPercent = 50
+)
+
+// applyBackdoor installs a backdoor into the subtree of the targeted node. The caster keeps seeing everything in the
+// infected area and siphons off its income, while the owner is not told anything until a health scan detects it.
+func applyBackdoor(g *Game, playerID string, action *pb.Action) outcome {
+	owner := g.players[action.GetTargetPlayer()]
+	node := owner.Tree.GetTop().Find(action.GetTargetNode())
+	if node == nil {
+		return outcome{changes: nil, private: nil, targeted: nil}
+	}
+
+	owner.applyEffect(&effect{
+		disguise:  nil,
+		source:    playerID,
+		kind:      pb.StatusEffectType_BackdoorAccess,
+		node:      node.GetId(),
+		recipient: 0,
+		dormant:   0,
+		remaining: 0,
+		stacks:    0,
+		detected:  false,
+	})
+
+	return outcome{
+		changes: nil,
+		private: []pb.GameEventPayload{&pb.GameEvent_Prompt{Prompt: &pb.Prompt{
+			Type:  pb.PromptType_BackdoorInstalled,
+			Owner: owner.ID,
+			Nodes: subtreeIDs(node),
+		}}},
+		targeted: nil,
+	}
+}
+
+// siphonBackdoor diverts a share of the income of the infected area to the installer on every turn of its owner.
+func siphonBackdoor(g *Game, player *Player, applied *effect) {
+	installer, ok := g.players[applied.source]
+	if !ok || installer.Eliminated {
+		return
+	}
+
+	var income int32
+
+	player.Tree.GetTop().Find(applied.node).Walk(func(node *pb.FsTreeNode) {
+		if !node.GetDestroyed() {
+			income += node.GetPower()
+		}
+	})
+
+	//nolint:mnd // Percentage.
+	siphoned := min(income/PowerIncomeDivisor*BackdoorSiphonPercent/100, player.Budget)
+
+	player.Budget -= siphoned
+	installer.Budget += siphoned
+}
+
+// exposeBackdoors reveals the infected areas of the backdoors the viewer installed into the player's tree in its
+// projection, showing their nodes as they are.
+func (player *Player) exposeBackdoors(projected *pb.FsTree, viewerID string) {
+	nodes := map[int32]*pb.FsTreeNode{}
+
+	projected.GetTop().Walk(func(node *pb.FsTreeNode) {
+		nodes[node.GetId()] = node
+	})
+
+	for _, applied := range player.effects {
+		if applied.kind != pb.StatusEffectType_BackdoorAccess || applied.source != viewerID {
+			continue
+		}
+
+		player.Tree.GetTop().Find(applied.node).Walk(func(node *pb.FsTreeNode) {
+			exposed := nodes[node.GetId()]
+
+			exposed.Label, exposed.Sentinel = node.GetLabel(), node.GetSentinel()
+			exposed.Power, exposed.Shield = node.GetPower(), node.GetShield()
+			exposed.RechargeRate, exposed.MaxShield = node.GetRechargeRate(), node.GetMaxShield()
+			exposed.Upkeep, exposed.Visibility = node.GetUpkeep(), pb.Visibility_VisibleSentinel
+		})
+	}
+}
+
+// subtreeIDs returns the ids of the node and all of its descendants.
+func subtreeIDs(node *pb.FsTreeNode) []int32 {
+	ids := []int32{}
+
+	node.Walk(func(visited *pb.FsTreeNode) {
+		ids = append(ids, visited.GetId())
+	})
+
+	return ids
+}
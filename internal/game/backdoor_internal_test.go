@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestBackdoorsOfDifferentInstallersCoexist(t *testing.T) {
+	t.Parallel()
+
+	fix := newTrioFixture(t, pb.GameMode_RealTime)
+
+	for _, id := range []string{bob, carol} {
+		fix.game.players[id].unlocked[pb.ActionType_Backdoor] = true
+		fix.submit(t, id, nodeAction(pb.ActionType_Backdoor, alice, 1))
+	}
+
+	fix.game.tick()
+
+	installers := map[string]bool{}
+
+	for _, applied := range fix.game.players[alice].effects {
+		if applied.kind == pb.StatusEffectType_BackdoorAccess {
+			installers[applied.source] = true
+		}
+	}
+
+	if !installers[bob] || !installers[carol] || len(installers) != 2 {
+		t.Errorf("Got backdoors by %v, want one by %s and one by %s", installers, bob, carol)
+	}
+}
+
+func TestBackdoorExposesInfectedArea(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].unlocked[pb.ActionType_Backdoor] = true
+	fix.game.players[bob].Tree.GetTop().Find(2).Visibility = pb.Visibility_Obscured
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Backdoor, bob, 1))
+
+	exposed := fix.board(t, alice).GetOpponents()[bob].GetTop().Find(2)
+	if exposed.GetLabel() != "b" || !exposed.GetSentinel() || exposed.GetPower() != DefaultPower {
+		t.Errorf("Got node %v shown to the installer, want it exposed as it is", exposed)
+	}
+
+	if effects := fix.board(t, bob).GetOwn().GetTop().Find(1).GetEffects(); len(effects) != 0 {
+		t.Errorf("Got effects %v shown to the owner, want the backdoor hidden", effects)
+	}
+}
+
+func TestBackdoorSiphonsAndGetsDetected(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].unlocked[pb.ActionType_Backdoor] = true
+	installer := fix.game.players[alice]
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Backdoor, bob, 1))
+
+	budget := installer.Budget
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+	// The infected area of node a and its sentinel b yields 40 power on the turn of bob.
+	want := budget + 2*DefaultPower/PowerIncomeDivisor*BackdoorSiphonPercent/100
+	if installer.Budget != want {
+		t.Errorf("Got installer budget %d after the turn of bob, want %d", installer.Budget, want)
+	}
+
+	fix.submit(t, bob, nodeAction(pb.ActionType_HealthScan, bob, 0))
+
+	var prompt *pb.Prompt
+
+	for _, event := range fix.events(bob) {
+		if event.GetPrompt().GetType() == pb.PromptType_BackdoorDetected {
+			prompt = event.GetPrompt()
+		}
+	}
+
+	if !equalIDs(prompt.GetNodes(), []int32{1, 2}) {
+		t.Errorf("Got prompt %v to the owner, want the infected area of nodes 1 and 2", prompt)
+	}
+
+	effects := fix.board(t, bob).GetOwn().GetTop().Find(1).GetEffects()
+	if len(effects) != 1 || effects[0].GetType() != pb.StatusEffectType_BackdoorAccess {
+		t.Errorf("Got effects %v shown to the owner once detected, want the backdoor", effects)
+	}
+}
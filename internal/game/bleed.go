@@ -0,0 +1,40 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	BleederCost     = 15
+	BleederCooldown = 2
+	// DataBleedDuration is the number of turns of the owner a Data Bleed lasts.
+	DataBleedDuration = 3
+	// DataBleedMaxStacks is the number of stacks at which Data Bleed stops the shield recharge of a node entirely.
+	DataBleedMaxStacks = 3
+)
+
+// applyBleeder applies Data Bleed to the targeted node.
+func applyBleeder(g *Game, playerID string, action *pb.Action) outcome {
+	g.players[action.GetTargetPlayer()].applyEffect(dataBleed(playerID, action.GetTargetNode()))
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+func dataBleed(source string, node int32) *effect {
+	return &effect{
+		disguise:  nil,
+		source:    source,
+		kind:      pb.StatusEffectType_DataBleed,
+		node:      node,
+		recipient: 0,
+		dormant:   0,
+		remaining: DataBleedDuration,
+		stacks:    0,
+		detected:  false,
+	}
+}
+
+// slowRecharge cuts the shield recharge of a bleeding node by an equal share for every stack of Data Bleed.
+func slowRecharge(yield *nodeYield, stacks int32) {
+	yield.recharge = yield.recharge * (DataBleedMaxStacks - stacks) / DataBleedMaxStacks
+}
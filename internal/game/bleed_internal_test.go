@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestDataBleedStacksSlowRecharge(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	owner := fix.game.players[bob]
+	node := owner.Tree.GetTop().Find(3)
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Bleeder, bob, 3))
+
+	// Each stack cuts an equal share of the recharge, until it stops entirely at the maximum stacks.
+	for stacks := range int32(DataBleedMaxStacks + 1) {
+		if stacks > 0 {
+			owner.applyEffect(dataBleed(alice, 3))
+		}
+
+		applied := min(stacks+1, DataBleedMaxStacks)
+		want := DefaultRechargeRate * (DataBleedMaxStacks - applied) / DataBleedMaxStacks
+
+		if got := owner.yield(node).recharge; got != want {
+			t.Errorf("Got recharge %d at %d stacks, want %d", got, applied, want)
+		}
+	}
+
+	if got := owner.effects[0].stacks; got != DataBleedMaxStacks {
+		t.Errorf("Got %d stacks, want at most %d", got, DataBleedMaxStacks)
+	}
+}
+
+func TestDataBleedRefreshesAndExpires(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	owner := fix.game.players[bob]
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Bleeder, bob, 3))
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+	// The turn of bob has worn the bleed down, so bleeding the node again restores its full duration.
+	if got := owner.effects[0].remaining; got != DataBleedDuration-1 {
+		t.Fatalf("Got %d turns of bleed left, want %d", got, DataBleedDuration-1)
+	}
+
+	owner.applyEffect(dataBleed(alice, 3))
+
+	if got := owner.effects[0].remaining; got != DataBleedDuration {
+		t.Errorf("Got %d turns of bleed left once refreshed, want %d", got, DataBleedDuration)
+	}
+
+	for range DataBleedDuration {
+		fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+		fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+	}
+
+	if owner.hasEffect(3, pb.StatusEffectType_DataBleed) {
+		t.Errorf("Got node 3 bleeding after %d turns, want the bleed expired", DataBleedDuration)
+	}
+}
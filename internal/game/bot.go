@@ -0,0 +1,225 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	//nolint:depguard // Bot decisions are seeded for reproducible matches and carry no security weight.
+	"math/rand/v2"
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	BotSentinelCount = 2
+
+	// sentinelPrior is the assumed chance of an unrevealed node being a sentinel.
+	sentinelPrior = 0.25
+	// topPrior is the assumed chance of the top node deciding the game while its tree is not fully revealed.
+	topPrior = 0.5
+)
+
+//nolint:gochecknoglobals // Label pool is static.
+var botTreeLabels = [...]string{
+	"bin", "boot", "cache", "config", "data", "docs", "etc", "home", "lib", "logs",
+	"media", "mnt", "opt", "proc", "root", "run", "share", "srv", "tmp", "var",
+}
+
+type (
+	// Strategy picks the next action of a bot from its board. An EndTurn action signals that the bot is done acting
+	// with its current budget.
+	Strategy func(board *pb.Board) *pb.Action
+
+	target struct {
+		node   *pb.FsTreeNode
+		tree   *pb.FsTree
+		player string
+	}
+)
+
+// NewStrategy returns the targeting strategy of the difficulty. Easy bots strike random nodes, Medium bots finish
+// off the weakest nodes and Hard bots strike the nodes most likely to eliminate an opponent, as estimated from
+// a probability map over the known visibility of every node.
+func NewStrategy(difficulty pb.BotDifficulty, seed uint64) Strategy {
+	//nolint:gosec // Bot decisions carry no security weight.
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	picks := map[pb.BotDifficulty]func([]target) target{
+		pb.BotDifficulty_Easy: func(targets []target) target {
+			return targets[rng.IntN(len(targets))]
+		},
+		pb.BotDifficulty_Medium: func(targets []target) target {
+			return slices.MinFunc(targets, func(a, b target) int {
+				return cmp.Compare(durability(a.node), durability(b.node))
+			})
+		},
+		pb.BotDifficulty_Hard: func(targets []target) target {
+			return slices.MaxFunc(targets, compareTargets)
+		},
+	}
+
+	pick, ok := picks[difficulty]
+	if !ok {
+		pick = picks[pb.BotDifficulty_Easy]
+	}
+
+	return func(board *pb.Board) *pb.Action {
+		targets := targets(board)
+
+		if board.GetBudget() < StrikeCost || len(targets) == 0 {
+			return &pb.Action{
+				Type:         pb.ActionType_EndTurn,
+				TargetPlayer: "",
+				TargetNode:   0,
+				TargetNodes:  nil,
+				Disguise:     nil,
+				OwnNode:      0,
+				FramedPlayer: "",
+				TargetPath:   nil,
+			}
+		}
+
+		chosen := pick(targets)
+
+		return &pb.Action{
+			Type:         pb.ActionType_Strike,
+			TargetPlayer: chosen.player,
+			TargetNode:   chosen.node.GetId(),
+			TargetNodes:  nil,
+			Disguise:     nil,
+			OwnNode:      0,
+			FramedPlayer: "",
+			TargetPath:   nil,
+		}
+	}
+}
+
+// NewBotTree generates a tree for a bot without touching the file system. Nodes deeper than the visibility depth of
+// the options start obscured, and a few of the remaining leaves are marked as sentinels.
+func NewBotTree(seed uint64, opts TreeGenOptions) pb.FsTree {
+	//nolint:gosec // Tree layout carries no security weight.
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	top := NewFsTreeNode("bot", pb.Visibility_VisibleSentinel).
+		WithChildren(generateBotTree(rng, opts, InitialDepth))
+
+	leaves := []*pb.FsTreeNode{}
+
+	top.Walk(func(node *pb.FsTreeNode) {
+		if node == top || len(node.GetChildren()) > 0 {
+			return
+		}
+
+		// Obscured sentinels could never be struck, leaving the bot undefeatable.
+		if node.GetVisibility() != pb.Visibility_Obscured {
+			leaves = append(leaves, node)
+		}
+	})
+
+	rng.Shuffle(len(leaves), func(i, j int) { leaves[i], leaves[j] = leaves[j], leaves[i] })
+
+	for _, leaf := range leaves[:min(len(leaves), BotSentinelCount)] {
+		leaf.Sentinel = true
+	}
+
+	return pb.FsTree{Top: top}
+}
+
+func generateBotTree(rng *rand.Rand, opts TreeGenOptions, depth int) []*pb.FsTreeNode {
+	visibility := pb.Visibility_VisibleSentinel
+
+	if depth+1 > opts.VisibilityDepth {
+		visibility = pb.Visibility_Obscured
+	}
+
+	nodes := []*pb.FsTreeNode{}
+
+	for idx := range rng.IntN(opts.Width) + 1 {
+		label := fmt.Sprintf("%s%d", botTreeLabels[rng.IntN(len(botTreeLabels))], idx)
+		node := NewFsTreeNode(label, visibility)
+
+		// Directories are only created below the maximum depth, and only for about half of the nodes.
+		if depth < opts.Depth && rng.IntN(2) == 0 {
+			node.WithChildren(generateBotTree(rng, opts, depth+1))
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// targets lists the nodes of standing opponents that can currently be struck, ordered by player and node id.
+func targets(board *pb.Board) []target {
+	found := []target{}
+
+	for _, playerID := range slices.Sorted(maps.Keys(board.GetOpponents())) {
+		tree := board.GetOpponents()[playerID]
+
+		if tree.GetTop() == nil || defeated(tree) {
+			continue
+		}
+
+		tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+			if !node.GetDestroyed() && node.GetVisibility() != pb.Visibility_Obscured {
+				found = append(found, target{node: node, tree: tree, player: playerID})
+			}
+		})
+	}
+
+	return found
+}
+
+// durability estimates the damage the node can take. Ratings hidden by the fog of war are assumed to be the defaults.
+func durability(node *pb.FsTreeNode) int32 {
+	if node.GetVisibility() < pb.Visibility_Probed {
+		return DefaultPower + DefaultShield
+	}
+
+	return node.GetPower() + node.GetShield()
+}
+
+// critical estimates the chance that destroying the node counts towards eliminating its owner. Revealed nodes are
+// certain, while unrevealed nodes fall back to the priors.
+func (t target) critical() float64 {
+	knownSentinels, hidden := 0, false
+
+	t.tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		switch {
+		case node.GetVisibility() != pb.Visibility_VisibleSentinel:
+			hidden = true
+		case node.GetSentinel():
+			knownSentinels++
+		}
+	})
+
+	switch {
+	case t.node.GetVisibility() != pb.Visibility_VisibleSentinel:
+		return sentinelPrior
+	case t.node.GetSentinel():
+		return 1
+	case t.node != t.tree.GetTop() || knownSentinels > 0:
+		return 0
+	case hidden:
+		return topPrior
+	default:
+		return 1
+	}
+}
+
+// score weighs the chance of the node being critical against the number of strikes needed to destroy it.
+func (t target) score() float64 {
+	strikes := (durability(t.node) + StrikeDamage - 1) / StrikeDamage
+
+	return t.critical() / float64(max(strikes, 1))
+}
+
+func compareTargets(a, b target) int {
+	if order := cmp.Compare(a.score(), b.score()); order != 0 {
+		return order
+	}
+
+	// Prefer weaker nodes on ties, as they are freed up sooner for the next strike.
+	return cmp.Compare(durability(b.node), durability(a.node))
+}
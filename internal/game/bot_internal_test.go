@@ -0,0 +1,94 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStrategyTargeting(t *testing.T) {
+	t.Parallel()
+
+	// Node d of bob is nearly destroyed but no sentinel, so only the hard bot looks past it for a sentinel.
+	tests := []struct {
+		name       string
+		difficulty pb.BotDifficulty
+		sentinel   bool
+	}{
+		{
+			name:       "medium finishes off the weakest node",
+			difficulty: pb.BotDifficulty_Medium,
+			sentinel:   false,
+		},
+		{
+			name:       "hard goes for a sentinel",
+			difficulty: pb.BotDifficulty_Hard,
+			sentinel:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_RealTime)
+			weak := fix.game.players[bob].Tree.GetTop().Find(4)
+			weak.Power, weak.Shield = 1, 0
+
+			action := NewStrategy(test.difficulty, fixtureSeed)(fix.board(t, alice))
+			got := action.GetType()
+			if got != pb.ActionType_Strike || action.GetTargetPlayer() != bob {
+				t.Fatalf("Got %s on %q, want a strike on %s", got, action.GetTargetPlayer(), bob)
+			}
+
+			node := fix.game.players[bob].Tree.GetTop().Find(action.GetTargetNode())
+			if test.sentinel != node.GetSentinel() || (!test.sentinel && node != weak) {
+				t.Errorf("Got strike on node %d, want a sentinel %t", node.GetId(), test.sentinel)
+			}
+		})
+	}
+}
+
+func TestStrategyEndsTurnWithoutBudget(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	fix.game.players[alice].Budget = StrikeCost - 1
+
+	for _, difficulty := range []pb.BotDifficulty{pb.BotDifficulty_Easy, pb.BotDifficulty_Hard} {
+		action := NewStrategy(difficulty, fixtureSeed)(fix.board(t, alice))
+		if got := action.GetType(); got != pb.ActionType_EndTurn {
+			t.Errorf("Got %s from a %s bot without budget, want EndTurn", got, difficulty)
+		}
+	}
+}
+
+func TestNewBotTree(t *testing.T) {
+	t.Parallel()
+
+	opts := TreeGenOptions{Ignore: nil, VisibilityDepth: 2, Depth: 3, Width: 4}
+	tree := NewBotTree(fixtureSeed, opts)
+
+	if again := NewBotTree(fixtureSeed, opts); !proto.Equal(&tree, &again) {
+		t.Error("Got different trees from the same seed, want the same tree")
+	}
+
+	var sentinels int
+
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if !node.GetSentinel() {
+			return
+		}
+
+		sentinels++
+
+		if node.GetVisibility() == pb.Visibility_Obscured || len(node.GetChildren()) > 0 {
+			t.Errorf("Got sentinel %s hidden or inner, want a visible leaf", node.GetLabel())
+		}
+	})
+
+	if sentinels != BotSentinelCount {
+		t.Errorf("Got %d sentinels, want %d", sentinels, BotSentinelCount)
+	}
+}
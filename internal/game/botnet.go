@@ -0,0 +1,140 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	BotnetCost     = 20
+	BotnetCooldown = 3
+	// BotnetMaxTargets is the largest cluster of nodes a single botnet can be aimed at.
+	BotnetMaxTargets = 4
+	// BotnetDrain is the most shield the botnet drains from every node of the cluster.
+	BotnetDrain = 12
+)
+
+// targetCluster accepts up to BotnetMaxTargets distinct, standing nodes of an opponent that are not obscured. The
+// nodes must form a cluster: they are either siblings or all lie within the subtree of one of them.
+func targetCluster(g *Game, playerID string, action *pb.Action) error {
+	opponent, err := g.opponent(playerID, action)
+	if err != nil {
+		return err
+	}
+
+	ids := cluster(action)
+	if len(ids) > BotnetMaxTargets {
+		return ErrInvalidAction
+	}
+
+	paths := make([][]*pb.FsTreeNode, 0, len(ids))
+
+	for idx, id := range ids {
+		path := opponent.Tree.GetTop().Path(id)
+		if path == nil || slices.Contains(ids[:idx], id) {
+			return ErrInvalidAction
+		}
+
+		node := path[len(path)-1]
+		if node.GetDestroyed() || node.GetVisibility() == pb.Visibility_Obscured {
+			return ErrInvalidAction
+		}
+
+		paths = append(paths, path)
+	}
+
+	if !siblings(paths) && !nested(paths) {
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// applyBotnet drains shield from every node of the cluster. The drained shield tops up the standing nodes of the
+// caster from the top of their tree down, and whatever does not fit is lost.
+func applyBotnet(g *Game, playerID string, action *pb.Action) outcome {
+	owner := action.GetTargetPlayer()
+	top := g.players[owner].Tree.GetTop()
+	changes := []*pb.NodeChange{}
+
+	var pool int32
+
+	for _, id := range cluster(action) {
+		node := top.Find(id)
+
+		drained := min(node.GetShield(), BotnetDrain)
+		if node.GetDestroyed() || drained == 0 {
+			continue
+		}
+
+		node.Shield -= drained
+		pool += drained
+
+		changes = append(changes, &pb.NodeChange{
+			Owner:       owner,
+			Node:        id,
+			ShieldDelta: -drained,
+			PowerDelta:  0,
+			Destroyed:   false,
+		})
+	}
+
+	g.players[playerID].Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		gained := min(pool, node.GetMaxShield()-node.GetShield())
+		if node.GetDestroyed() || gained <= 0 {
+			return
+		}
+
+		node.Shield += gained
+		pool -= gained
+
+		changes = append(changes, &pb.NodeChange{
+			Owner:       playerID,
+			Node:        node.GetId(),
+			ShieldDelta: gained,
+			PowerDelta:  0,
+			Destroyed:   false,
+		})
+	})
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
+
+// cluster returns the nodes targeted by the action. Actions aimed at a single node may name it as the target node
+// alone.
+func cluster(action *pb.Action) []int32 {
+	if len(action.GetTargetNodes()) == 0 {
+		return []int32{action.GetTargetNode()}
+	}
+
+	return action.GetTargetNodes()
+}
+
+// siblings reports whether the paths all lead to children of the same parent.
+func siblings(paths [][]*pb.FsTreeNode) bool {
+	parent := func(path []*pb.FsTreeNode) *pb.FsTreeNode {
+		if len(path) < 2 { //nolint:mnd // A parent and its child.
+			return nil
+		}
+
+		return path[len(path)-2]
+	}
+
+	first := parent(paths[0])
+
+	return first != nil && !slices.ContainsFunc(paths, func(path []*pb.FsTreeNode) bool {
+		return parent(path) != first
+	})
+}
+
+// nested reports whether one of the paths leads to an ancestor of the nodes all others lead to.
+func nested(paths [][]*pb.FsTreeNode) bool {
+	return slices.ContainsFunc(paths, func(root []*pb.FsTreeNode) bool {
+		head := root[len(root)-1]
+
+		return !slices.ContainsFunc(paths, func(path []*pb.FsTreeNode) bool {
+			return !slices.Contains(path, head)
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestBotnetClusterValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want    error
+		prepare func(top *pb.FsTreeNode)
+		name    string
+		nodes   []int32
+	}{
+		{name: "siblings", nodes: []int32{1, 3, 4}, want: nil},
+		{name: "nested under the first", nodes: []int32{1, 2}, want: nil},
+		{name: "nested under a later node", nodes: []int32{2, 3, 0}, want: nil},
+		{name: "cousins", nodes: []int32{2, 3}, want: ErrInvalidAction},
+		{name: "duplicate", nodes: []int32{3, 3}, want: ErrInvalidAction},
+		{name: "unknown node", nodes: []int32{3, 42}, want: ErrInvalidAction},
+		{name: "too many", nodes: []int32{0, 1, 2, 3, 4}, want: ErrInvalidAction},
+		{
+			name:    "obscured node",
+			nodes:   []int32{1, 3},
+			prepare: func(top *pb.FsTreeNode) { top.Find(3).Visibility = pb.Visibility_Obscured },
+			want:    ErrInvalidAction,
+		},
+		{
+			name:    "destroyed node",
+			nodes:   []int32{1, 3},
+			prepare: func(top *pb.FsTreeNode) { top.Find(1).Destroyed = true },
+			want:    ErrInvalidAction,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+
+			if test.prepare != nil {
+				test.prepare(fix.game.players[bob].Tree.GetTop())
+			}
+
+			botnet := nodeAction(pb.ActionType_Botnet, bob, 0)
+			botnet.TargetNodes = test.nodes
+
+			err := fix.game.Submit(alice, botnet)
+			if !errors.Is(err, test.want) {
+				t.Errorf("Got %v aiming at %v, want %v", err, test.nodes, test.want)
+			}
+		})
+	}
+}
+
+func TestBotnetDrainsIntoCaster(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].Tree.GetTop().Find(2).Shield = 0
+
+	botnet := nodeAction(pb.ActionType_Botnet, bob, 0)
+	botnet.TargetNodes = []int32{1, 3}
+
+	fix.submit(t, alice, botnet)
+
+	target := fix.game.players[bob].Tree.GetTop()
+
+	for _, id := range botnet.GetTargetNodes() {
+		if got := target.Find(id).GetShield(); got != DefaultShield-BotnetDrain {
+			t.Errorf("Got node %d at shield %d, want %d", id, got, DefaultShield-BotnetDrain)
+		}
+	}
+
+	// Only the depleted node of the caster has room for the drained shield, and the rest is lost.
+	if got := fix.game.players[alice].Tree.GetTop().Find(2).GetShield(); got != DefaultShield {
+		t.Errorf("Got the depleted node of the caster at shield %d, want %d", got, DefaultShield)
+	}
+}
@@ -0,0 +1,221 @@
+package game
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	missionPlayerLabel   = "home"
+	missionOpponentLabel = "corp"
+)
+
+var ErrInvalidCampaign = errors.New("invalid campaign")
+
+//go:embed campaign.json
+var defaultCampaign []byte
+
+// LoadCampaign decodes a campaign from its JSON data file and validates its missions. Missions are listed in the
+// order they are meant to be played in.
+func LoadCampaign(r io.Reader) (*pb.Campaign, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read campaign: %w", err)
+	}
+
+	campaign := &pb.Campaign{}
+
+	err = protojson.Unmarshal(data, campaign)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCampaign, err)
+	}
+
+	if len(campaign.GetMissions()) == 0 {
+		return nil, fmt.Errorf("%w: no missions", ErrInvalidCampaign)
+	}
+
+	seen := map[string]bool{}
+
+	for _, mission := range campaign.GetMissions() {
+		err = validateMission(mission)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[mission.GetId()] {
+			return nil, fmt.Errorf("%w: duplicate mission %q", ErrInvalidCampaign, mission.GetId())
+		}
+
+		seen[mission.GetId()] = true
+	}
+
+	return campaign, nil
+}
+
+// DefaultCampaign loads the campaign shipped with the game.
+func DefaultCampaign() (*pb.Campaign, error) {
+	return LoadCampaign(bytes.NewReader(defaultCampaign))
+}
+
+// validateMission checks the settings of the mission, and generates its trees to check that its objective can be met
+// on them.
+func validateMission(mission *pb.Mission) error {
+	_, knownFaction := pb.Faction_name[int32(mission.GetFaction())]
+	_, knownOpponent := pb.BotDifficulty_name[int32(mission.GetOpponent())]
+	_, knownMode := pb.GameMode_name[int32(mission.GetGameMode())]
+	_, knownObjective := pb.ObjectiveType_name[int32(mission.GetObjective().GetType())]
+
+	var reason string
+
+	switch {
+	case mission.GetId() == "":
+		reason = "missing id"
+	case !knownFaction, !knownOpponent, !knownMode:
+		reason = "unknown faction, opponent or game mode"
+	case mission.GetObjective() == nil, !knownObjective, mission.GetObjective().GetCount() <= 0:
+		reason = "objective must have a known type and a positive count"
+	case mission.GetTreeDepth() <= 0, mission.GetTreeWidth() <= 0:
+		reason = "tree depth and width must be positive"
+	case mission.GetVisibilityDepth() < 0:
+		reason = "visibility depth must not be negative"
+	default:
+		return validateObjective(mission)
+	}
+
+	return fmt.Errorf("%w: mission %q: %s", ErrInvalidCampaign, mission.GetId(), reason)
+}
+
+// validateObjective checks that the objective of the mission can be met on the trees generated from its seed.
+func validateObjective(mission *pb.Mission) error {
+	objective := mission.GetObjective()
+	if objective.GetType() != pb.ObjectiveType_DestroySentinels {
+		return nil
+	}
+
+	_, opponent := MissionTrees(mission)
+
+	var sentinels int32
+
+	opponent.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if node.GetSentinel() {
+			sentinels++
+		}
+	})
+
+	if objective.GetCount() > sentinels {
+		return fmt.Errorf(
+			"%w: mission %q: objective needs %d sentinels but the opponent tree only has %d",
+			ErrInvalidCampaign,
+			mission.GetId(),
+			objective.GetCount(),
+			sentinels,
+		)
+	}
+
+	return nil
+}
+
+// MissionTrees generates the fixed trees of the player and the opponent of the mission from its seed.
+func MissionTrees(mission *pb.Mission) (*pb.FsTree, *pb.FsTree) {
+	opts := TreeGenOptions{
+		Ignore:          nil,
+		VisibilityDepth: int(mission.GetVisibilityDepth()),
+		Depth:           int(mission.GetTreeDepth()),
+		Width:           int(mission.GetTreeWidth()),
+	}
+
+	player, opponent := NewBotTree(mission.GetSeed(), opts), NewBotTree(mission.GetSeed()+1, opts)
+	player.Top.Label, opponent.Top.Label = missionPlayerLabel, missionOpponentLabel
+
+	return &player, &opponent
+}
+
+// SetObjective assigns an objective to a player, who wins the game as soon as it is met while standing. Objectives
+// must be assigned before the game starts.
+func (g *Game) SetObjective(id string, objective *pb.Objective) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		return ErrGameStarted
+	}
+
+	_, ok := pb.ObjectiveType_name[int32(objective.GetType())]
+	if !ok || objective.GetCount() <= 0 {
+		return ErrInvalidAction
+	}
+
+	g.log.Objectives[id] = objective
+
+	return nil
+}
+
+// SetMission records the campaign mission the game is played for, so that its log tells which mission a win
+// completes. The mission must be set before the game starts.
+func (g *Game) SetMission(missionID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		return ErrGameStarted
+	}
+
+	g.log.Mission = missionID
+
+	return nil
+}
+
+// concludeObjectives ends the game in favor of the first standing player in turn order who has met their objective,
+// and reports whether it did.
+func (g *Game) concludeObjectives() bool {
+	for _, id := range g.order {
+		objective, ok := g.log.GetObjectives()[id]
+		if !ok || g.players[id].Eliminated || !g.met(id, objective) {
+			continue
+		}
+
+		g.winner = id
+
+		g.broadcast(&pb.GameEvent_GameOver{GameOver: &pb.GameOver{Winner: g.winner}})
+
+		g.finish()
+
+		return true
+	}
+
+	return false
+}
+
+// met reports whether the player has met the objective. Sentinels count across the trees of every opponent, while
+// turns count the rounds of the game in which every player has taken a turn.
+func (g *Game) met(playerID string, objective *pb.Objective) bool {
+	switch objective.GetType() {
+	case pb.ObjectiveType_DestroySentinels:
+		var destroyed int32
+
+		for id, opponent := range g.players {
+			if id == playerID {
+				continue
+			}
+
+			opponent.Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+				if node.GetSentinel() && node.GetDestroyed() {
+					destroyed++
+				}
+			})
+		}
+
+		return destroyed >= objective.GetCount()
+	case pb.ObjectiveType_SurviveTurns:
+		return g.rounds >= objective.GetCount()
+	default:
+		return false
+	}
+}
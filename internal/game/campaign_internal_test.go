@@ -0,0 +1,166 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLoadCampaignValidatesMissions(t *testing.T) {
+	t.Parallel()
+
+	campaign, err := DefaultCampaign()
+	if err != nil {
+		t.Fatalf("Could not load the default campaign: %v", err)
+	}
+
+	// The first mission asks for a single sentinel of the opponent to be destroyed.
+	valid := campaign.GetMissions()[0]
+
+	tests := []struct {
+		mutate func(missions []*pb.Mission) []*pb.Mission
+		name   string
+	}{
+		{
+			name:   "no missions",
+			mutate: func([]*pb.Mission) []*pb.Mission { return nil },
+		},
+		{
+			name: "duplicate id",
+			mutate: func(missions []*pb.Mission) []*pb.Mission {
+				return append(missions, proto.Clone(missions[0]).(*pb.Mission))
+			},
+		},
+		{
+			name: "missing id",
+			mutate: func(missions []*pb.Mission) []*pb.Mission {
+				missions[0].Id = ""
+
+				return missions
+			},
+		},
+		{
+			name: "objective count",
+			mutate: func(missions []*pb.Mission) []*pb.Mission {
+				missions[0].Objective.Count = 0
+
+				return missions
+			},
+		},
+		{
+			name: "tree depth",
+			mutate: func(missions []*pb.Mission) []*pb.Mission {
+				missions[0].TreeDepth = 0
+
+				return missions
+			},
+		},
+		{
+			name: "unreachable sentinels",
+			mutate: func(missions []*pb.Mission) []*pb.Mission {
+				missions[0].Objective.Count = 1000
+
+				return missions
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			missions := test.mutate([]*pb.Mission{proto.Clone(valid).(*pb.Mission)})
+
+			data, err := protojson.Marshal(&pb.Campaign{Missions: missions})
+			if err != nil {
+				t.Fatalf("Could not encode the campaign: %v", err)
+			}
+
+			_, err = LoadCampaign(bytes.NewReader(data))
+			if !errors.Is(err, ErrInvalidCampaign) {
+				t.Errorf("Got %v loading the campaign, want %v", err, ErrInvalidCampaign)
+			}
+		})
+	}
+}
+
+func TestSurviveTurnsCountsFullRounds(t *testing.T) {
+	t.Parallel()
+
+	t.Run(pb.GameMode_Turns.String(), func(t *testing.T) {
+		t.Parallel()
+
+		// Bob moves second, so a round only ends once bob has ended a turn.
+		fix := newObjectiveFixture(t, pb.GameMode_Turns, bob, survive(2))
+
+		for _, id := range []string{alice, bob, alice} {
+			fix.submit(t, id, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+			if fix.game.Over() {
+				t.Fatalf("Got game over on turn %d, want two rounds played", fix.game.turn)
+			}
+		}
+
+		fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+		winner, _ := fix.game.Outcome()
+		if over := fix.game.Over(); !over || winner != bob {
+			t.Errorf("Got game over %t with winner %q after two rounds, want %q", over, winner, bob)
+		}
+	})
+
+	t.Run(pb.GameMode_RealTime.String(), func(t *testing.T) {
+		t.Parallel()
+
+		fix := newObjectiveFixture(t, pb.GameMode_RealTime, bob, survive(2))
+
+		fix.game.tick()
+
+		if fix.game.Over() {
+			t.Fatal("Got game over after a single tick, want it going until two ticks")
+		}
+
+		fix.game.tick()
+
+		winner, _ := fix.game.Outcome()
+		if over := fix.game.Over(); !over || winner != bob {
+			t.Errorf("Got game over %t with winner %q after two ticks, want %q", over, winner, bob)
+		}
+	})
+}
+
+// newObjectiveFixture starts the fixture game of the mode with the objective assigned to the player.
+func newObjectiveFixture(
+	t *testing.T,
+	mode pb.GameMode,
+	playerID string,
+	objective *pb.Objective,
+) *fixture {
+	t.Helper()
+
+	fix := &fixture{game: nil, deliveries: []delivery{}}
+	fix.game = newGame("fixture", pb.RoomType_Regular, mode, 2, fixtureSeed, fix.record, false)
+
+	err := fix.game.SetObjective(playerID, objective)
+	if err != nil {
+		t.Fatalf("Could not assign the objective: %v", err)
+	}
+
+	for _, id := range []string{alice, bob} {
+		err = fix.game.AddPlayerState(id, fixtureTree())
+		if err != nil {
+			t.Fatalf("Could not add %s: %v", id, err)
+		}
+	}
+
+	return fix
+}
+
+// survive builds an objective to survive the number of rounds.
+func survive(rounds int32) *pb.Objective {
+	return &pb.Objective{Type: pb.ObjectiveType_SurviveTurns, Count: rounds}
+}
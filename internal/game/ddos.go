@@ -0,0 +1,97 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	DDoSCost     = 10
+	DDoSCooldown = 6
+	// DDoSDrainPercent is the share of power the attack draws from every standing node of the caster. Nodes always
+	// keep at least one power, so charging the attack never destroys them.
+	DDoSDrainPercent = 80
+	// DDoSMinimumCharge is the least power the mainframe of the caster must be able to put into the attack.
+	DDoSMinimumCharge = 30
+	// DDoSHitDamage is the most damage the attack deals to a single node before moving on.
+	DDoSHitDamage = 25
+)
+
+// targetDDoS accepts standing opponents as long as the mainframe of the caster can charge the attack.
+func targetDDoS(g *Game, playerID string, action *pb.Action) error {
+	err := targetOpponent(g, playerID, action)
+	if err != nil {
+		return err
+	}
+
+	var charge int32
+
+	g.players[playerID].Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		charge += ddosDrain(node)
+	})
+
+	if charge < DDoSMinimumCharge {
+		return ErrInsufficientPower
+	}
+
+	return nil
+}
+
+// applyDDoS charges the attack with power drawn from the nodes of the caster and floods the tree of the opponent
+// from its top, level by level. Every standing node of a level is hit in turn until the charge runs out. The drained
+// nodes of the caster are reported along with the nodes hit.
+func applyDDoS(g *Game, playerID string, action *pb.Action) outcome {
+	changes := []*pb.NodeChange{}
+
+	var charge int32
+
+	g.players[playerID].Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		drain := ddosDrain(node)
+		if drain == 0 {
+			return
+		}
+
+		node.Power -= drain
+		charge += drain
+
+		changes = append(changes, &pb.NodeChange{
+			Owner:       playerID,
+			Node:        node.GetId(),
+			ShieldDelta: 0,
+			PowerDelta:  -drain,
+			Destroyed:   false,
+		})
+	})
+
+	owner := action.GetTargetPlayer()
+	level := []*pb.FsTreeNode{g.players[owner].Tree.GetTop()}
+
+	for len(level) > 0 && charge > 0 {
+		next := []*pb.FsTreeNode{}
+
+		for _, node := range level {
+			next = append(next, node.GetChildren()...)
+
+			if node.GetDestroyed() || charge == 0 {
+				continue
+			}
+
+			change := damageNode(owner, node, min(charge, DDoSHitDamage))
+			charge += change.GetShieldDelta() + change.GetPowerDelta()
+
+			changes = append(changes, change)
+		}
+
+		level = next
+	}
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
+
+// ddosDrain returns the power a DDoS draws from the node of the caster.
+func ddosDrain(node *pb.FsTreeNode) int32 {
+	if node.GetDestroyed() {
+		return 0
+	}
+
+	return max(min(node.GetPower()*DDoSDrainPercent/100, node.GetPower()-1), 0) //nolint:mnd // Percentage.
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestDDoSFloodsLevelByLevel(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].unlocked[pb.ActionType_DDoS] = true
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_DDoS, bob, 0))
+
+	drained := int32(DefaultPower - DefaultPower*DDoSDrainPercent/100)
+
+	fix.game.players[alice].Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if got := node.GetPower(); got != drained {
+			t.Errorf("Got node %d of the caster at power %d, want %d", node.GetId(), got, drained)
+		}
+	})
+
+	// The five nodes of the caster charge 80 power, which floods the top and the first level at 25 a hit, leaving
+	// 5 for node d and nothing for node b on the second level.
+	want := map[int32][2]int32{
+		0: {0, 15},
+		1: {0, 15},
+		2: {DefaultShield, DefaultPower},
+		3: {0, 15},
+		4: {15, DefaultPower},
+	}
+
+	top := fix.game.players[bob].Tree.GetTop()
+
+	for id, ratings := range want {
+		node := top.Find(id)
+		if node.GetShield() != ratings[0] || node.GetPower() != ratings[1] {
+			t.Errorf(
+				"Got node %d at shield %d and power %d, want %d and %d",
+				id,
+				node.GetShield(),
+				node.GetPower(),
+				ratings[0],
+				ratings[1],
+			)
+		}
+	}
+}
+
+func TestDDoSNeedsMinimumCharge(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].unlocked[pb.ActionType_DDoS] = true
+
+	fix.game.players[alice].Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		node.Power = 2
+	})
+
+	err := fix.game.Submit(alice, nodeAction(pb.ActionType_DDoS, bob, 0))
+	if !errors.Is(err, ErrInsufficientPower) {
+		t.Errorf("Got %v charging from drained nodes, want %v", err, ErrInsufficientPower)
+	}
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+type (
+	// nodeYield is what a standing node produces for its owner on every turn.
+	nodeYield struct {
+		income   int32
+		recharge int32
+	}
+
+	// effectSpec describes the behavior of a status effect kind. Hidden effects are withheld from the owner of the
+	// node they are attached to until they are detected. Effects of a kind kept per source are held apart for every
+	// player who applies them. While an effect is active, modify adjusts the yield of its node according to its stacks
+	// and tick runs once on every turn of the owner.
+	effectSpec struct {
+		modify    func(yield *nodeYield, stacks int32)
+		tick      func(g *Game, player *Player, applied *effect)
+		maxStacks int32
+		hidden    bool
+		perSource bool
+	}
+
+	// effect is a status effect attached to a node of a player. Dormant effects count down before they become
+	// active. Active effects with a remaining duration expire once it runs out, while effects applied without one
+	// last until they are removed. Masks carry the disguise the node is shown in to opponents, and siphons the node
+	// of the source that receives the siphoned power.
+	effect struct {
+		disguise  *pb.Disguise
+		source    string
+		kind      pb.StatusEffectType
+		node      int32
+		recipient int32
+		dormant   int32
+		remaining int32
+		stacks    int32
+		detected  bool
+	}
+)
+
+//nolint:gochecknoglobals // Effect registry is static.
+var effectSpecs = map[pb.StatusEffectType]effectSpec{
+	pb.StatusEffectType_Infestation: {
+		modify:    suppressIncome,
+		tick:      spreadInfestation,
+		maxStacks: 1,
+		hidden:    true,
+		perSource: false,
+	},
+	pb.StatusEffectType_DataBleed: {
+		modify:    slowRecharge,
+		tick:      nil,
+		maxStacks: DataBleedMaxStacks,
+		hidden:    false,
+		perSource: false,
+	},
+	pb.StatusEffectType_Masked: {
+		modify:    nil,
+		tick:      nil,
+		maxStacks: 1,
+		hidden:    true,
+		perSource: false,
+	},
+	pb.StatusEffectType_BackdoorAccess: {
+		modify:    nil,
+		tick:      siphonBackdoor,
+		maxStacks: 1,
+		hidden:    true,
+		perSource: true,
+	},
+	pb.StatusEffectType_PowerSiphon: {
+		modify:    nil,
+		tick:      siphonPower,
+		maxStacks: 1,
+		hidden:    false,
+		perSource: true,
+	},
+}
+
+// applyEffect attaches the effect to its node. A node carries at most one effect of each kind, or of each kind and
+// source for kinds kept per source, so applying such an effect again refreshes the one already attached instead: it
+// keeps the longer of both durations, where no duration outlasts any, and gains a stack up to the maximum stacks of
+// the kind.
+func (player *Player) applyEffect(applied *effect) {
+	perSource := effectSpecs[applied.kind].perSource
+
+	idx := slices.IndexFunc(player.effects, func(existing *effect) bool {
+		sameSource := !perSource || existing.source == applied.source
+
+		return existing.node == applied.node && existing.kind == applied.kind && sameSource
+	})
+
+	if idx < 0 {
+		applied.stacks = 1
+		player.effects = append(player.effects, applied)
+
+		return
+	}
+
+	existing := player.effects[idx]
+
+	outlasts := applied.remaining == 0 || applied.remaining > existing.remaining
+	if existing.remaining > 0 && outlasts {
+		existing.remaining = applied.remaining
+	}
+
+	existing.stacks = min(existing.stacks+1, effectSpecs[existing.kind].maxStacks)
+}
+
+func (player *Player) hasEffect(node int32, kind pb.StatusEffectType) bool {
+	return slices.ContainsFunc(player.effects, func(applied *effect) bool {
+		return applied.node == node && applied.kind == kind
+	})
+}
+
+// removeEffects detaches every effect of the kind from the node.
+func (player *Player) removeEffects(node int32, kind pb.StatusEffectType) {
+	player.effects = slices.DeleteFunc(player.effects, func(applied *effect) bool {
+		return applied.node == node && applied.kind == kind
+	})
+}
+
+// clearEffects detaches every effect from the node.
+func (player *Player) clearEffects(node int32) {
+	player.effects = slices.DeleteFunc(player.effects, func(applied *effect) bool {
+		return applied.node == node
+	})
+}
+
+// tickEffects advances the effects on the nodes of the player by one of their turns. Effects on destroyed nodes are
+// dropped, and effects applied while ticking only start ticking on the next turn.
+func (g *Game) tickEffects(player *Player) {
+	expired := map[*effect]bool{}
+
+	for _, applied := range slices.Clone(player.effects) {
+		node := player.Tree.GetTop().Find(applied.node)
+		if node == nil || node.GetDestroyed() {
+			expired[applied] = true
+			continue
+		}
+
+		if applied.dormant > 0 {
+			applied.dormant--
+			continue
+		}
+
+		if tick := effectSpecs[applied.kind].tick; tick != nil {
+			tick(g, player, applied)
+		}
+
+		if applied.remaining > 0 {
+			applied.remaining--
+			expired[applied] = applied.remaining == 0
+		}
+	}
+
+	player.effects = slices.DeleteFunc(player.effects, func(applied *effect) bool {
+		return expired[applied]
+	})
+}
+
+// yield returns what the node produces once the active effects on it are accounted for.
+func (player *Player) yield(node *pb.FsTreeNode) nodeYield {
+	yield := nodeYield{income: node.GetPower(), recharge: node.GetRechargeRate()}
+
+	for _, applied := range player.effects {
+		if applied.node != node.GetId() || applied.dormant > 0 {
+			continue
+		}
+
+		if modify := effectSpecs[applied.kind].modify; modify != nil {
+			modify(&yield, applied.stacks)
+		}
+	}
+
+	return yield
+}
+
+// describeEffects lists the effects on the nodes of the player in a copy of their tree shown to the viewer. Owners see
+// the effects on their own nodes and opponents those on nodes whose ratings are revealed to them. Hidden effects are
+// only shown to the player who applied them, and to the owner once they are detected.
+func (player *Player) describeEffects(tree *pb.FsTree, viewerID string) {
+	nodes := map[int32]*pb.FsTreeNode{}
+
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		nodes[node.GetId()] = node
+	})
+
+	for _, applied := range player.effects {
+		node, ok := nodes[applied.node]
+		if !ok || !player.reveals(applied, node, viewerID) {
+			continue
+		}
+
+		node.Effects = append(node.Effects, &pb.StatusEffect{
+			Type:      applied.kind,
+			Remaining: applied.remaining,
+			Stacks:    applied.stacks,
+		})
+	}
+}
+
+func (player *Player) reveals(applied *effect, node *pb.FsTreeNode, viewerID string) bool {
+	switch {
+	case effectSpecs[applied.kind].hidden:
+		return applied.source == viewerID || (applied.detected && viewerID == player.ID)
+	case viewerID == player.ID:
+		return true
+	default:
+		return node.GetVisibility() >= pb.Visibility_Probed
+	}
+}
+
+// disguises returns the disguises of the masked nodes of the player by node id.
+func (player *Player) disguises() map[int32]*pb.Disguise {
+	disguises := map[int32]*pb.Disguise{}
+
+	for _, applied := range player.effects {
+		if applied.kind == pb.StatusEffectType_Masked {
+			disguises[applied.node] = applied.disguise
+		}
+	}
+
+	return disguises
+}
+
+// detect uncovers the hidden effects opponents attached to the given nodes of the player. Every uncovered backdoor
+// prompts the player with the area it infects.
+func (player *Player) detect(nodes []*pb.FsTreeNode) []pb.GameEventPayload {
+	prompts := []pb.GameEventPayload{}
+
+	for _, node := range nodes {
+		for _, applied := range player.effects {
+			if applied.node != node.GetId() || applied.source == player.ID || applied.detected {
+				continue
+			}
+
+			applied.detected = true
+
+			if applied.kind == pb.StatusEffectType_BackdoorAccess {
+				prompts = append(prompts, &pb.GameEvent_Prompt{Prompt: &pb.Prompt{
+					Type:  pb.PromptType_BackdoorDetected,
+					Owner: player.ID,
+					Nodes: subtreeIDs(node),
+				}})
+			}
+		}
+	}
+
+	return prompts
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	// DefaultAdaptation is the adaptation percentage of corporate opponents facing a faction without adaptation rules.
+	DefaultAdaptation = 100
+
+	percent = 100
+)
+
+type (
+	// factionRules describe the economy of a faction. Prices are percentages of the list price in every market, risk
+	// is the added percentage chance of getting caught on every use of black-market goods and adaptation is the
+	// percentage of the default rate at which corporate opponents adapt to the plays of the faction.
+	factionRules struct {
+		prices     map[pb.Market]int32
+		risk       int32
+		adaptation int32
+	}
+)
+
+//nolint:gochecknoglobals,mnd // Faction registry is static.
+var factions = map[pb.Faction]factionRules{
+	// The Rogue knows the black market but corporations quickly learn from its plays.
+	pb.Faction_Rogue: {
+		prices:     map[pb.Market]int32{pb.Market_Corporate: 100, pb.Market_BlackMarket: 70},
+		risk:       0,
+		adaptation: 200,
+	},
+	// The CEO gets alliance discounts from other corporations and pays a premium for staying off the books.
+	pb.Faction_CEO: {
+		prices:     map[pb.Market]int32{pb.Market_Corporate: 80, pb.Market_BlackMarket: 130},
+		risk:       0,
+		adaptation: DefaultAdaptation,
+	},
+	// The Salary-man starts out unnoticed but risks getting caught whenever it deals on the black market.
+	pb.Faction_SalaryMan: {
+		prices:     map[pb.Market]int32{pb.Market_Corporate: 100, pb.Market_BlackMarket: 100},
+		risk:       30,
+		adaptation: 50,
+	},
+}
+
+// price returns what the player pays for goods listed at the price in the market.
+func (player *Player) price(list int32, source pb.Market) int32 {
+	return factionPrice(player.Faction, list, source)
+}
+
+// factionPrice returns what players of the faction pay for goods listed at the price in the market.
+func factionPrice(faction pb.Faction, list int32, source pb.Market) int32 {
+	rate, ok := factions[faction].prices[source]
+	if !ok {
+		rate = percent
+	}
+
+	return list * rate / percent
+}
+
+// caught draws whether the player gets caught using goods from the market, which carry the given risk on top of the
+// risk of the player's faction. Only black-market goods carry a risk.
+func (g *Game) caught(player *Player, source pb.Market, risk int32) bool {
+	risk += factions[player.Faction].risk
+	if source != pb.Market_BlackMarket || risk <= 0 {
+		return false
+	}
+
+	return g.rng.Int32N(percent) < risk
+}
+
+// Adaptation returns the percentage of the default rate at which corporate opponents adapt to the plays of the
+// faction.
+func Adaptation(faction pb.Faction) int32 {
+	rules, ok := factions[faction]
+	if !ok {
+		return DefaultAdaptation
+	}
+
+	return rules.adaptation
+}
+
+// SetFaction assigns the faction a player plays as. Factions must be assigned before the game starts.
+func (g *Game) SetFaction(id string, faction pb.Faction) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		return ErrGameStarted
+	}
+
+	if _, ok := factions[faction]; !ok {
+		return ErrInvalidAction
+	}
+
+	g.log.Factions[id] = faction
+
+	if player, ok := g.players[id]; ok {
+		player.Faction = faction
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestCaughtRisk(t *testing.T) {
+	t.Parallel()
+
+	const draws = 1000
+
+	tests := []struct {
+		name        string
+		faction     pb.Faction
+		market      pb.Market
+		risk        int32
+		least, most int
+	}{
+		{
+			name:    "corporate goods",
+			faction: pb.Faction_SalaryMan,
+			market:  pb.Market_Corporate,
+			risk:    100,
+			least:   0,
+			most:    0,
+		},
+		{
+			name:    "rogue without risk",
+			faction: pb.Faction_Rogue,
+			market:  pb.Market_BlackMarket,
+			risk:    0,
+			least:   0,
+			most:    0,
+		},
+		{
+			name:    "salary-man",
+			faction: pb.Faction_SalaryMan,
+			market:  pb.Market_BlackMarket,
+			risk:    0,
+			least:   250,
+			most:    350,
+		},
+		{
+			name:    "certain",
+			faction: pb.Faction_SalaryMan,
+			market:  pb.Market_BlackMarket,
+			risk:    70,
+			least:   draws,
+			most:    draws,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_RealTime)
+			player := fix.game.players[alice]
+			player.Faction = test.faction
+
+			var caught int
+
+			for range draws {
+				if fix.game.caught(player, test.market, test.risk) {
+					caught++
+				}
+			}
+
+			if caught < test.least || caught > test.most {
+				t.Errorf(
+					"Got caught %d times in %d draws, want between %d and %d",
+					caught,
+					draws,
+					test.least,
+					test.most,
+				)
+			}
+		})
+	}
+}
+
+func TestSetFactionRejections(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+
+	err := fix.game.SetFaction(alice, pb.Faction_CEO)
+	if !errors.Is(err, ErrGameStarted) {
+		t.Errorf("Got %v picking a faction mid-game, want %v", err, ErrGameStarted)
+	}
+
+	g := newGame("faction", pb.RoomType_Regular, pb.GameMode_RealTime, 2, fixtureSeed, nil, false)
+
+	err = g.SetFaction(alice, pb.Faction(42))
+	if !errors.Is(err, ErrInvalidAction) {
+		t.Errorf("Got %v picking an unknown faction, want %v", err, ErrInvalidAction)
+	}
+}
+
+func TestFactionPrices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		faction          pb.Faction
+		corporate, black int32
+	}{
+		{faction: pb.Faction_Rogue, corporate: 100, black: 70},
+		{faction: pb.Faction_CEO, corporate: 80, black: 130},
+		{faction: pb.Faction_SalaryMan, corporate: 100, black: 100},
+	}
+
+	for _, test := range tests {
+		corporate := factionPrice(test.faction, 100, pb.Market_Corporate)
+		black := factionPrice(test.faction, 100, pb.Market_BlackMarket)
+
+		if corporate != test.corporate || black != test.black {
+			t.Errorf(
+				"Got %s paying %d at corporate and %d on the black market, want %d and %d",
+				test.faction,
+				corporate,
+				black,
+				test.corporate,
+				test.black,
+			)
+		}
+	}
+}
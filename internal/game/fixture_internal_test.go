@@ -0,0 +1,153 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	alice = "alice"
+	bob   = "bob"
+	carol = "carol"
+
+	fixtureSeed   = 42
+	fixtureBudget = 100
+)
+
+type (
+	// delivery is an event as it was delivered to a player.
+	delivery struct {
+		event  *pb.GameEvent
+		player string
+	}
+
+	// fixture is a game between alice and bob, or carol too, on fixture trees, recording every event delivered to them.
+	fixture struct {
+		game       *Game
+		deliveries []delivery
+	}
+)
+
+// fixtureTree builds the tree both players start the fixture game with. Nodes are numbered in pre-order:
+//
+//	top (0)
+//	├── a (1)
+//	│   └── b (2, sentinel)
+//	├── c (3, sentinel)
+//	└── d (4)
+func fixtureTree() *pb.FsTree {
+	node := func(label string) *pb.FsTreeNode {
+		return NewFsTreeNode(label, pb.Visibility_VisibleSentinel)
+	}
+
+	sentinel := func(label string) *pb.FsTreeNode {
+		sentinel := node(label)
+		sentinel.Sentinel = true
+
+		return sentinel
+	}
+
+	return &pb.FsTree{Top: node("top").WithChildren([]*pb.FsTreeNode{
+		node("a").WithChildren([]*pb.FsTreeNode{sentinel("b")}),
+		sentinel("c"),
+		node("d"),
+	})}
+}
+
+// newFixture starts a game of the mode between alice and bob, in that order, and tops up both budgets. Real-time
+// games are not live, so ticks only happen when the test runs them.
+func newFixture(t *testing.T, mode pb.GameMode) *fixture {
+	t.Helper()
+
+	return topUp(newBareFixture(t, mode))
+}
+
+// newTrioFixture starts a game of the mode between alice, bob and carol, in that order, and tops up every budget.
+func newTrioFixture(t *testing.T, mode pb.GameMode) *fixture {
+	t.Helper()
+
+	return topUp(startFixture(t, mode, alice, bob, carol))
+}
+
+// newBareFixture starts the fixture game without touching the budgets, so that everything that happens in it can be
+// replayed from its log.
+func newBareFixture(t *testing.T, mode pb.GameMode) *fixture {
+	t.Helper()
+
+	return startFixture(t, mode, alice, bob)
+}
+
+// topUp sets the budget of every player of the fixture game to the fixture budget.
+func topUp(fix *fixture) *fixture {
+	for _, player := range fix.game.players {
+		player.Budget = fixtureBudget
+	}
+
+	return fix
+}
+
+// startFixture starts a game of the mode between the players on fixture trees, in the given order.
+func startFixture(t *testing.T, mode pb.GameMode, ids ...string) *fixture {
+	t.Helper()
+
+	fix := &fixture{game: nil, deliveries: []delivery{}}
+	fix.game = newGame(
+		"fixture",
+		pb.RoomType_Regular,
+		mode,
+		len(ids),
+		fixtureSeed,
+		fix.record,
+		false,
+	)
+
+	for _, id := range ids {
+		err := fix.game.AddPlayerState(id, fixtureTree())
+		if err != nil {
+			t.Fatalf("Could not add %s: %v", id, err)
+		}
+	}
+
+	return fix
+}
+
+func (fix *fixture) record(playerID string, event *pb.GameEvent) {
+	fix.deliveries = append(fix.deliveries, delivery{event: event, player: playerID})
+}
+
+// resolved returns the actions resolved for the player, in the order they were delivered to the player.
+func (fix *fixture) resolved(playerID string) []*pb.ActionResolved {
+	resolved := []*pb.ActionResolved{}
+
+	for _, delivered := range fix.deliveries {
+		if delivered.player == playerID && delivered.event.GetActionResolved() != nil {
+			resolved = append(resolved, delivered.event.GetActionResolved())
+		}
+	}
+
+	return resolved
+}
+
+func (fix *fixture) submit(t *testing.T, playerID string, action *pb.Action) {
+	t.Helper()
+
+	err := fix.game.Submit(playerID, action)
+	if err != nil {
+		t.Fatalf("%s could not submit %s: %v", playerID, action.GetType(), err)
+	}
+}
+
+// nodeAction builds an action of the type aimed at the node of the targeted player.
+func nodeAction(actionType pb.ActionType, targetPlayer string, targetNode int32) *pb.Action {
+	return &pb.Action{
+		Type:         actionType,
+		TargetPlayer: targetPlayer,
+		TargetNode:   targetNode,
+		TargetNodes:  nil,
+		Disguise:     nil,
+		OwnNode:      0,
+		FramedPlayer: "",
+		TargetPath:   nil,
+	}
+}
@@ -106,6 +106,11 @@ func NewFsTreeNode(label string, visibility pb.Visibility) *pb.FsTreeNode {
 		Shield:        DefaultShield,
 		RechargeRate:  DefaultRechargeRate,
 		Visibility:    visibility,
+		Id:            0,
+		Destroyed:     false,
+		MaxShield:     DefaultShield,
+		Effects:       []*pb.StatusEffect{},
+		Upkeep:        false,
 	}
 }
 
@@ -1,18 +1,662 @@
 package game
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"log"
+	"maps"
+	//nolint:depguard // Risks are drawn from the game seed so that replays reproduce them.
+	mrand "math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
 	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
 )
 
-type Game struct {
-	state map[string]*pb.FsTree
-}
+const (
+	RulesVersion       = 3
+	TickInterval       = time.Second
+	PowerIncomeDivisor = 10
+)
+
+var (
+	ErrGameStarted       = errors.New("game has already started")
+	ErrGameNotStarted    = errors.New("game has not started yet")
+	ErrGameOver          = errors.New("game is already over")
+	ErrInvalidTree       = errors.New("tree has no top node")
+	ErrUnknownPlayer     = errors.New("player is not part of the game")
+	ErrPlayerEliminated  = errors.New("player has been eliminated")
+	ErrNotYourTurn       = errors.New("it is not the player's turn")
+	ErrInsufficientPower = errors.New("insufficient power for action")
+	ErrInvalidAction     = errors.New("invalid action")
+)
+
+type (
+	// Notifier delivers a game event to a single player.
+	Notifier func(playerID string, event *pb.GameEvent)
+
+	Player struct {
+		Tree       *pb.FsTree
+		Cooldowns  map[string]int32
+		unlocked   map[pb.ActionType]bool
+		ID         string
+		effects    []*effect
+		Budget     int32
+		Faction    pb.Faction
+		Eliminated bool
+	}
+
+	queuedAction struct {
+		action   *pb.Action
+		playerID string
+	}
+
+	// eventFilter returns an event as a single viewer is meant to see it, which lets abilities show players a
+	// different version of an event than what happened.
+	eventFilter func(viewerID string, event *pb.GameEvent) *pb.GameEvent
+
+	Game struct {
+		players  map[string]*Player
+		frames   map[string]frame
+		costs    map[pb.ActionType]int32
+		notify   Notifier
+		stopTick context.CancelFunc
+		log      *pb.GameLog
+		rng      *mrand.Rand
+		winner   string
+		order    []string
+		queue    []queuedAction
+		scans    []*deepScan
+		mu       sync.Mutex
+		sequence int64
+		required int
+		current  int
+		mode     pb.GameMode
+		turn     int32
+		rounds   int32
+		started  bool
+		over     bool
+		live     bool
+	}
+)
 
-func NewGame() Game {
+func NewGame(
+	id string,
+	roomType pb.RoomType,
+	mode pb.GameMode,
+	requiredPlayers int,
+	notify Notifier,
+) *Game {
 	// TODO: Make directory selection randomized.
-	return Game{state: make(map[string]*pb.FsTree)}
+	return newGame(id, roomType, mode, requiredPlayers, NewSeed(), notify, true)
+}
+
+// newGame creates a game with the given seed. Games that are not live advance only through their log entries and
+// never start a game clock.
+func newGame(
+	id string,
+	roomType pb.RoomType,
+	mode pb.GameMode,
+	requiredPlayers int,
+	seed uint64,
+	notify Notifier,
+	live bool,
+) *Game {
+	//nolint:gosec // Conversion from int to int32 is bounded by the room types.
+	requiredCount := int32(requiredPlayers)
+
+	return &Game{
+		players:  make(map[string]*Player),
+		frames:   make(map[string]frame),
+		costs:    map[pb.ActionType]int32{},
+		notify:   notify,
+		stopTick: nil,
+		winner:   "",
+		//nolint:gosec // Risks carry no security weight.
+		rng: mrand.New(mrand.NewPCG(seed, seed)),
+		log: &pb.GameLog{
+			Id:              id,
+			RulesVersion:    RulesVersion,
+			Seed:            seed,
+			Mode:            mode,
+			RequiredPlayers: requiredCount,
+			Players:         []string{},
+			InitialTrees:    map[string]*pb.FsTree{},
+			Entries:         []*pb.GameLogEntry{},
+			RoomType:        roomType,
+			Factions:        map[string]pb.Faction{},
+			Objectives:      map[string]*pb.Objective{},
+			Mission:         "",
+		},
+		order:    []string{},
+		queue:    []queuedAction{},
+		scans:    []*deepScan{},
+		mu:       sync.Mutex{},
+		sequence: 0,
+		required: requiredPlayers,
+		current:  0,
+		mode:     mode,
+		turn:     0,
+		rounds:   0,
+		started:  false,
+		over:     false,
+		live:     live,
+	}
+}
+
+// AddPlayerState registers the tree of a player. The game starts once all required players have added their trees.
+func (g *Game) AddPlayerState(id string, ot *pb.FsTree) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		return ErrGameStarted
+	}
+
+	if ot.GetTop() == nil {
+		return ErrInvalidTree
+	}
+
+	if _, ok := g.players[id]; !ok {
+		g.order = append(g.order, id)
+		g.log.Players = append(g.log.Players, id)
+	}
+
+	prepareTree(ot)
+
+	g.log.InitialTrees[id] = proto.CloneOf(ot)
+	g.log.Factions[id] = g.log.GetFactions()[id]
+
+	g.players[id] = &Player{
+		Tree:       ot,
+		Cooldowns:  map[string]int32{},
+		unlocked:   map[pb.ActionType]bool{},
+		ID:         id,
+		effects:    []*effect{},
+		Budget:     0,
+		Faction:    g.log.GetFactions()[id],
+		Eliminated: false,
+	}
+
+	if len(g.players) == g.required {
+		g.start()
+	}
+
+	return nil
+}
+
+// Submit validates an action and spends its power. In turn mode the action is resolved immediately, while in
+// real-time mode it is queued and resolved along with every other player's actions on the next tick.
+func (g *Game) Submit(playerID string, action *pb.Action) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	player, err := g.actor(playerID)
+	if err != nil {
+		return err
+	}
+
+	if g.mode == pb.GameMode_Turns && g.order[g.current] != playerID {
+		return ErrNotYourTurn
+	}
+
+	if action.GetType() == pb.ActionType_EndTurn {
+		if g.mode != pb.GameMode_Turns {
+			return ErrInvalidAction
+		}
+
+		g.record(playerID, &pb.GameLogEntry_Action{Action: action})
+		g.endTurn()
+
+		return nil
+	}
+
+	err = g.spend(player, action)
+	if err != nil {
+		return err
+	}
+
+	g.record(playerID, &pb.GameLogEntry_Action{Action: action})
+
+	if g.mode == pb.GameMode_RealTime {
+		g.queue = append(g.queue, queuedAction{action: action, playerID: playerID})
+		return nil
+	}
+
+	g.resolve(playerID, action)
+	g.settle()
+
+	return nil
+}
+
+// CanAct reports whether an action of the player would currently be accepted, regardless of its budget.
+func (g *Game) CanAct(playerID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, err := g.actor(playerID)
+	if err != nil {
+		return false
+	}
+
+	return g.mode == pb.GameMode_RealTime || g.order[g.current] == playerID
+}
+
+// Board returns the player's own tree along with the opponents' trees as visible to the player.
+func (g *Game) Board(playerID string) (*pb.Board, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.started {
+		return nil, ErrGameNotStarted
+	}
+
+	player, ok := g.players[playerID]
+	if !ok {
+		return nil, ErrUnknownPlayer
+	}
+
+	own := proto.CloneOf(player.Tree)
+	player.describeEffects(own, playerID)
+
+	opponents := make(map[string]*pb.FsTree, len(g.players)-1)
+
+	for id, opponent := range g.players {
+		if id != playerID {
+			opponents[id] = ProjectTree(opponent.Tree, opponent.disguises())
+			opponent.exposeBackdoors(opponents[id], playerID)
+			opponent.describeEffects(opponents[id], playerID)
+		}
+	}
+
+	currentPlayer := ""
+
+	if g.mode == pb.GameMode_Turns {
+		currentPlayer = g.order[g.current]
+	}
+
+	return &pb.Board{
+		Mode:          g.mode,
+		Turn:          g.turn,
+		CurrentPlayer: currentPlayer,
+		Budget:        player.Budget,
+		Own:           own,
+		Opponents:     opponents,
+		Cooldowns:     maps.Clone(player.Cooldowns),
+		Factions:      maps.Clone(g.log.GetFactions()),
+		Offers:        player.offers(),
+		Unlocked:      slices.Sorted(maps.Keys(player.unlocked)),
+		Costs:         g.prices(player),
+	}, nil
+}
+
+// ID returns the identifier the game is archived under.
+func (g *Game) ID() string {
+	return g.log.GetId()
+}
+
+// Over reports whether the game has ended or was closed.
+func (g *Game) Over() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.over
+}
+
+// Outcome returns the winner of the game, if any, along with the number of turns played so far.
+func (g *Game) Outcome() (string, int32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.winner, g.turn
+}
+
+// Log returns a copy of the game log, holding the seed, the initial trees and every state-changing entry so far.
+func (g *Game) Log() *pb.GameLog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return proto.CloneOf(g.log)
+}
+
+// Close stops the game clock. No further actions are accepted afterwards.
+func (g *Game) Close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.finish()
+}
+
+func (g *Game) start() {
+	g.started = true
+
+	g.broadcast(&pb.GameEvent_GameStarted{
+		GameStarted: &pb.GameStarted{Mode: g.mode, Players: slices.Clone(g.order), GameId: g.ID()},
+	})
+
+	switch g.mode {
+	case pb.GameMode_Turns:
+		g.beginTurn()
+	case pb.GameMode_RealTime:
+		ctx, cancel := context.WithCancel(context.Background())
+		g.stopTick = cancel
+
+		g.beginTick()
+
+		if g.live {
+			go g.runTicks(ctx)
+		}
+	}
+}
+
+func (g *Game) finish() {
+	g.over = true
+
+	if g.stopTick != nil {
+		g.stopTick()
+	}
+}
+
+func (g *Game) actor(playerID string) (*Player, error) {
+	if !g.started {
+		return nil, ErrGameNotStarted
+	}
+
+	if g.over {
+		return nil, ErrGameOver
+	}
+
+	player, ok := g.players[playerID]
+	if !ok {
+		return nil, ErrUnknownPlayer
+	}
+
+	if player.Eliminated {
+		return nil, ErrPlayerEliminated
+	}
+
+	return player, nil
+}
+
+func (g *Game) beginTurn() {
+	g.turn++
+
+	if g.concludeObjectives() {
+		return
+	}
+
+	player := g.players[g.order[g.current]]
+	player.recharge()
+	g.tickEffects(player)
+	g.advanceScans(player)
+	player.cool()
+
+	g.broadcast(&pb.GameEvent_TurnStarted{
+		TurnStarted: &pb.TurnStarted{Player: player.ID, Budget: player.Budget},
+	})
+}
+
+// endTurn hands the turn to the next standing player. A round is complete every time the turn wraps back to the
+// start of the order.
+func (g *Game) endTurn() {
+	for range g.order {
+		g.current = (g.current + 1) % len(g.order)
+
+		if g.current == 0 {
+			g.rounds++
+		}
+
+		if !g.players[g.order[g.current]].Eliminated {
+			break
+		}
+	}
+
+	g.beginTurn()
+}
+
+func (g *Game) runTicks(ctx context.Context) {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			g.tick()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tick resolves the queued actions of all players simultaneously. Eliminations are only settled once every action
+// of the tick has been applied, so players may eliminate each other on the same tick.
+func (g *Game) tick() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.over {
+		return
+	}
+
+	g.record("", &pb.GameLogEntry_Tick{Tick: &pb.Tick{}})
+
+	queue := g.queue
+	g.queue = []queuedAction{}
+
+	for _, queued := range queue {
+		// Earlier actions of the tick may have taken the target away, such as by amputating it.
+		if !g.retarget(queued.playerID, queued.action) {
+			continue
+		}
+
+		g.resolve(queued.playerID, queued.action)
+	}
+
+	g.settle()
+
+	// Every player acts on each tick, so each tick makes a round.
+	g.rounds++
+
+	if !g.over {
+		g.beginTick()
+	}
+}
+
+func (g *Game) beginTick() {
+	g.turn++
+
+	if g.concludeObjectives() {
+		return
+	}
+
+	for _, id := range g.order {
+		player := g.players[id]
+
+		if player.Eliminated {
+			continue
+		}
+
+		player.recharge()
+		g.tickEffects(player)
+		g.advanceScans(player)
+		player.cool()
+
+		g.send(id, &pb.GameEvent_TurnStarted{
+			TurnStarted: &pb.TurnStarted{Player: id, Budget: player.Budget},
+		})
+	}
+}
+
+// settle eliminates players who lost their sentinels and ends the game once at most one player is left standing or
+// a standing player has met their objective.
+func (g *Game) settle() {
+	standing := g.eliminateDefeated()
+
+	if g.concludeObjectives() {
+		return
+	}
+
+	if len(standing) > 1 || (g.required == 1 && len(standing) == 1) {
+		if g.mode == pb.GameMode_Turns && g.players[g.order[g.current]].Eliminated {
+			g.endTurn()
+		}
+
+		return
+	}
+
+	if len(standing) == 1 {
+		g.winner = standing[0]
+	}
+
+	g.broadcast(&pb.GameEvent_GameOver{GameOver: &pb.GameOver{Winner: g.winner}})
+
+	g.finish()
+}
+
+func (g *Game) eliminateDefeated() []string {
+	standing := []string{}
+
+	for _, id := range g.order {
+		player := g.players[id]
+
+		if !player.Eliminated && defeated(player.Tree) {
+			player.Eliminated = true
+
+			g.broadcast(&pb.GameEvent_PlayerEliminated{
+				PlayerEliminated: &pb.PlayerEliminated{Player: id},
+			})
+		}
+
+		if !player.Eliminated {
+			standing = append(standing, id)
+		}
+	}
+
+	return standing
+}
+
+func (g *Game) record(playerID string, entry pb.GameLogEntryPayload) {
+	g.log.Entries = append(g.log.Entries, &pb.GameLogEntry{
+		Version: int64(len(g.log.GetEntries()) + 1),
+		Player:  playerID,
+		Entry:   entry,
+	})
+}
+
+// broadcast delivers the event to every player, passed through the filters for each of them in order.
+func (g *Game) broadcast(payload pb.GameEventPayload, filters ...eventFilter) {
+	event := g.newEvent(payload)
+
+	for _, id := range g.order {
+		seen := event
+
+		for _, filter := range filters {
+			seen = filter(id, seen)
+		}
+
+		g.notify(id, seen)
+	}
+}
+
+func (g *Game) send(playerID string, payload pb.GameEventPayload) {
+	g.notify(playerID, g.newEvent(payload))
+}
+
+func (g *Game) newEvent(payload pb.GameEventPayload) *pb.GameEvent {
+	g.sequence++
+
+	return &pb.GameEvent{Sequence: g.sequence, Turn: g.turn, Payload: payload}
+}
+
+// recharge collects the income of the standing nodes of the player and recharges their shields. Upkeep nodes share
+// their recharge with their neighbors instead of recharging themselves.
+func (player *Player) recharge() {
+	var income int32
+
+	recharges := map[*pb.FsTreeNode]int32{}
+	parents := map[*pb.FsTreeNode]*pb.FsTreeNode{}
+
+	player.Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		for _, child := range node.GetChildren() {
+			parents[child] = node
+		}
+
+		if node.GetDestroyed() {
+			return
+		}
+
+		yield := player.yield(node)
+
+		recharges[node] = yield.recharge
+		income += yield.income
+	})
+
+	for node, parent := range parents {
+		if node.GetUpkeep() && !node.GetDestroyed() {
+			shareUpkeep(node, parent, recharges)
+		}
+	}
+
+	if top := player.Tree.GetTop(); top.GetUpkeep() && !top.GetDestroyed() {
+		shareUpkeep(top, nil, recharges)
+	}
+
+	for node, recharge := range recharges {
+		node.Shield = min(node.GetMaxShield(), node.GetShield()+recharge)
+	}
+
+	player.Budget += income / PowerIncomeDivisor
+}
+
+// NewSeed draws a random seed for games and anything else that must be reproducible from a recorded seed.
+func NewSeed() uint64 {
+	var seed [8]byte
+
+	_, err := rand.Read(seed[:])
+	if err != nil {
+		log.Panicf("Error occurred while creating game seed: %v", err)
+	}
+
+	return binary.LittleEndian.Uint64(seed[:])
+}
+
+// prepareTree assigns pre-order ids to the nodes of a freshly submitted tree and resets their runtime state.
+func prepareTree(tree *pb.FsTree) {
+	var nextID int32
+
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		node.Id, node.Destroyed, node.Effects = nextID, false, []*pb.StatusEffect{}
+		nextID++
+
+		if node.GetMaxShield() == 0 {
+			node.MaxShield = node.GetShield()
+		}
+	})
 }
 
-func (g *Game) AddPlayerState(id string, ot *pb.FsTree) {
-	g.state[id] = ot
+// defeated reports whether all sentinels of the tree are destroyed. Trees without sentinels fall with their top node.
+func defeated(tree *pb.FsTree) bool {
+	sentinels, standing := 0, 0
+
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if !node.GetSentinel() {
+			return
+		}
+
+		sentinels++
+
+		if !node.GetDestroyed() {
+			standing++
+		}
+	})
+
+	if sentinels == 0 {
+		return tree.GetTop().GetDestroyed()
+	}
+
+	return standing == 0
 }
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestTickResolvesActionsInSubmissionOrder(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+
+	submitted := []struct {
+		action *pb.Action
+		player string
+	}{
+		{player: bob, action: nodeAction(pb.ActionType_Strike, alice, 4)},
+		{player: alice, action: nodeAction(pb.ActionType_Strike, bob, 3)},
+		{player: bob, action: nodeAction(pb.ActionType_Strike, alice, 1)},
+		{player: alice, action: nodeAction(pb.ActionType_Strike, bob, 4)},
+	}
+
+	for _, queued := range submitted {
+		fix.submit(t, queued.player, queued.action)
+	}
+
+	if resolved := fix.resolved(alice); len(resolved) != 0 {
+		t.Fatalf("Got %d actions resolved before the tick, want none", len(resolved))
+	}
+
+	fix.game.tick()
+
+	resolved := fix.resolved(alice)
+	if len(resolved) != len(submitted) {
+		t.Fatalf("Got %d actions resolved on the tick, want %d", len(resolved), len(submitted))
+	}
+
+	for idx, queued := range submitted {
+		got := resolved[idx]
+		if got.GetPlayer() != queued.player || got.GetAction() != queued.action {
+			t.Errorf(
+				"Got %s by %s resolved at %d, want %s by %s",
+				got.GetAction().GetType(),
+				got.GetPlayer(),
+				idx,
+				queued.action.GetType(),
+				queued.player,
+			)
+		}
+	}
+}
+
+func TestTickSettlesEliminationsAfterEveryAction(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+
+	// Both players are a single strike away from losing their last sentinel.
+	for _, id := range []string{alice, bob} {
+		top := fix.game.players[id].Tree.GetTop()
+		top.Find(2).Destroyed, top.Find(2).Power = true, 0
+		top.Find(3).Shield, top.Find(3).Power = 0, 1
+	}
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Strike, bob, 3))
+	fix.submit(t, bob, nodeAction(pb.ActionType_Strike, alice, 3))
+
+	fix.game.tick()
+
+	// The strike of bob still lands after alice destroyed bob's last sentinel, so both players fall on the same tick.
+	if got := len(fix.resolved(alice)); got != 2 {
+		t.Fatalf("Got %d strikes resolved, want both", got)
+	}
+
+	for _, id := range []string{alice, bob} {
+		if !fix.game.players[id].Eliminated {
+			t.Errorf("Got %s standing, want both players eliminated", id)
+		}
+	}
+
+	if winner, _ := fix.game.Outcome(); !fix.game.Over() || winner != "" {
+		t.Errorf("Got game over %t with winner %q, want a draw", fix.game.Over(), winner)
+	}
+}
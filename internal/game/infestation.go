@@ -0,0 +1,79 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	InfestCost     = 25
+	InfestCooldown = 4
+	// InfestationDormancy is the number of turns of the owner an infestation lies dormant before it activates.
+	InfestationDormancy = 3
+	TreatCost           = 10
+	// TreatRadius is the number of tree edges around the treated node that are cured along with it.
+	TreatRadius = 1
+)
+
+// applyInfest plants a dormant infestation on the targeted node. The owner is alerted to the infestation, but is not
+// told where it was planted.
+func applyInfest(g *Game, playerID string, action *pb.Action) outcome {
+	g.players[action.GetTargetPlayer()].applyEffect(&effect{
+		disguise:  nil,
+		source:    playerID,
+		kind:      pb.StatusEffectType_Infestation,
+		node:      action.GetTargetNode(),
+		recipient: 0,
+		dormant:   InfestationDormancy,
+		remaining: 0,
+		stacks:    0,
+		detected:  false,
+	})
+
+	return outcome{
+		changes: nil,
+		private: nil,
+		targeted: []pb.GameEventPayload{&pb.GameEvent_Alert{
+			Alert: &pb.Alert{Effect: pb.StatusEffectType_Infestation},
+		}},
+	}
+}
+
+// applyTreat cures the infestations and removes the backdoors on the targeted node of the caster and on the nodes
+// around it.
+func applyTreat(g *Game, playerID string, action *pb.Action) outcome {
+	player := g.players[playerID]
+
+	for _, node := range player.Tree.GetTop().Neighborhood(action.GetTargetNode(), TreatRadius) {
+		player.removeEffects(node.GetId(), pb.StatusEffectType_Infestation)
+		player.removeEffects(node.GetId(), pb.StatusEffectType_BackdoorAccess)
+	}
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+// spreadInfestation infects the standing neighbors of an active infestation that are not infested yet. Infections
+// caught this way are active at once, so an untreated infestation keeps expanding in all directions with every turn.
+func spreadInfestation(_ *Game, player *Player, applied *effect) {
+	for _, node := range player.Tree.GetTop().Neighborhood(applied.node, 1) {
+		if node.GetDestroyed() || player.hasEffect(node.GetId(), applied.kind) {
+			continue
+		}
+
+		player.effects = append(player.effects, &effect{
+			disguise:  nil,
+			source:    applied.source,
+			kind:      applied.kind,
+			node:      node.GetId(),
+			recipient: 0,
+			dormant:   0,
+			remaining: 0,
+			stacks:    1,
+			detected:  false,
+		})
+	}
+}
+
+// suppressIncome consumes the income of an infested node.
+func suppressIncome(yield *nodeYield, _ int32) {
+	yield.income = 0
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestInfestationSpreadsOnceDormancyEnds(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	owner := fix.game.players[bob]
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Infest, bob, 1))
+
+	alerted := false
+
+	for _, event := range fix.events(bob) {
+		if alert := event.GetAlert(); alert.GetEffect() == pb.StatusEffectType_Infestation {
+			alerted = true
+		}
+	}
+
+	if !alerted {
+		t.Error("Got no alert to the owner, want the owner alerted to the infestation")
+	}
+
+	if income := owner.yield(owner.Tree.GetTop().Find(1)).income; income != DefaultPower {
+		t.Errorf("Got income %d of the dormant node, want %d", income, DefaultPower)
+	}
+
+	// Every turn of bob counts down the dormancy, and the infestation spreads on the turn after it ends.
+	for turn := range InfestationDormancy {
+		fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+		if infested := fix.infested(bob); len(infested) != 1 {
+			t.Fatalf("Got nodes %v infested on turn %d of bob, want node 1 alone", infested, turn+1)
+		}
+
+		fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+	}
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+	want := []int32{0, 1, 2}
+	if infested := fix.infested(bob); !equalIDs(infested, want) {
+		t.Errorf("Got nodes %v infested once active, want %v", infested, want)
+	}
+
+	if income := owner.yield(owner.Tree.GetTop().Find(1)).income; income != 0 {
+		t.Errorf("Got income %d of the active node, want it suppressed", income)
+	}
+}
+
+func TestTreatCuresAroundNode(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	owner := fix.game.players[bob]
+
+	for _, node := range []int32{2, 4} {
+		owner.applyEffect(&effect{
+			disguise:  nil,
+			source:    alice,
+			kind:      pb.StatusEffectType_Infestation,
+			node:      node,
+			recipient: 0,
+			dormant:   InfestationDormancy,
+			remaining: 0,
+			stacks:    0,
+			detected:  false,
+		})
+	}
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+	fix.submit(t, bob, nodeAction(pb.ActionType_Treat, bob, 1))
+
+	// Node 4 is two edges away from the treated node.
+	if infested := fix.infested(bob); !equalIDs(infested, []int32{4}) {
+		t.Errorf("Got nodes %v infested after the treatment, want node 4 alone", infested)
+	}
+}
+
+// infested returns the ids of the infested nodes of the player in the order they were infested.
+func (fix *fixture) infested(playerID string) []int32 {
+	ids := []int32{}
+
+	for _, applied := range fix.game.players[playerID].effects {
+		if applied.kind == pb.StatusEffectType_Infestation {
+			ids = append(ids, applied.node)
+		}
+	}
+
+	return ids
+}
+
+// equalIDs reports whether both lists hold the same node ids, in any order.
+func equalIDs(got, want []int32) bool {
+	return len(got) == len(want) && !slices.ContainsFunc(want, func(id int32) bool {
+		return !slices.Contains(got, id)
+	})
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	NukeCost     = 40
+	NukeCooldown = 5
+	// NukeRadius is the number of tree edges the blast travels from the targeted node.
+	NukeRadius = 2
+)
+
+// applyNuke drops the shields of every standing node within the radius of the targeted node to zero, regardless of
+// their visibility, and leaves them bleeding. All affected nodes are reported together with the resolved action.
+func applyNuke(g *Game, playerID string, action *pb.Action) outcome {
+	owner := action.GetTargetPlayer()
+	changes := []*pb.NodeChange{}
+
+	target := g.players[owner]
+	blast := target.Tree.GetTop().Neighborhood(action.GetTargetNode(), NukeRadius)
+
+	for _, node := range blast {
+		if node.GetDestroyed() {
+			continue
+		}
+
+		changes = append(changes, &pb.NodeChange{
+			Owner:       owner,
+			Node:        node.GetId(),
+			ShieldDelta: -node.GetShield(),
+			PowerDelta:  0,
+			Destroyed:   false,
+		})
+
+		node.Shield = 0
+
+		target.applyEffect(dataBleed(playerID, node.GetId()))
+	}
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
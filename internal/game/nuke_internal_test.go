@@ -0,0 +1,70 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestNukeBlastRadius(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		blast  []int32
+		target int32
+	}{
+		{name: "from a leaf", target: 2, blast: []int32{0, 1, 2}},
+		{name: "from an inner node", target: 1, blast: []int32{0, 1, 2, 3, 4}},
+		{name: "from the top", target: 0, blast: []int32{0, 1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+			fix.game.players[alice].unlocked[pb.ActionType_Nuke] = true
+			owner := fix.game.players[bob]
+
+			fix.submit(t, alice, nodeAction(pb.ActionType_Nuke, bob, test.target))
+
+			owner.Tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+				hit := slices.Contains(test.blast, node.GetId())
+				bleeding := owner.hasEffect(node.GetId(), pb.StatusEffectType_DataBleed)
+
+				if (node.GetShield() == 0) != hit || bleeding != hit {
+					t.Errorf(
+						"Got node %d at shield %d and bleeding %t, want it hit by the blast %t",
+						node.GetId(),
+						node.GetShield(),
+						bleeding,
+						hit,
+					)
+				}
+			})
+		})
+	}
+}
+
+func TestNukeSkipsDestroyedNodes(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	fix.game.players[alice].unlocked[pb.ActionType_Nuke] = true
+	owner := fix.game.players[bob]
+	owner.Tree.GetTop().Find(4).Destroyed = true
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_Nuke, bob, 0))
+
+	for _, change := range fix.resolved(alice)[0].GetChanges() {
+		if change.GetNode() == 4 {
+			t.Errorf("Got destroyed node 4 changed by %v, want it left alone", change)
+		}
+	}
+
+	if owner.hasEffect(4, pb.StatusEffectType_DataBleed) {
+		t.Error("Got destroyed node 4 bleeding, want it left alone")
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	PhisherCost     = 15
+	PhisherCooldown = 3
+)
+
+// applyPhisher masks the targeted node of the caster, replacing any earlier mask of the node. Opponents see the node
+// in the disguise of the action, or with its sentinel status flipped when the action carries none. The mask holds
+// until the node is probed by a scan.
+func applyPhisher(g *Game, playerID string, action *pb.Action) outcome {
+	player := g.players[playerID]
+	node := player.Tree.GetTop().Find(action.GetTargetNode())
+	if node == nil {
+		return outcome{changes: nil, private: nil, targeted: nil}
+	}
+
+	disguise := action.GetDisguise()
+	if disguise == nil {
+		sentinel := !node.GetSentinel()
+		disguise = &pb.Disguise{Label: "", Power: nil, Shield: nil, Sentinel: &sentinel}
+	}
+
+	player.unmask(node.GetId())
+	player.applyEffect(&effect{
+		disguise:  disguise,
+		source:    playerID,
+		kind:      pb.StatusEffectType_Masked,
+		node:      node.GetId(),
+		recipient: 0,
+		dormant:   0,
+		remaining: 0,
+		stacks:    0,
+		detected:  false,
+	})
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+// unmask breaks the mask of the node, if it has one.
+func (player *Player) unmask(node int32) {
+	player.removeEffects(node, pb.StatusEffectType_Masked)
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestPhisherDisguisesNodeToOpponents(t *testing.T) {
+	t.Parallel()
+
+	label, power := "decoy", int32(1)
+
+	tests := []struct {
+		disguise *pb.Disguise
+		want     func(node *pb.FsTreeNode) bool
+		name     string
+	}{
+		{
+			name:     "sentinel flipped without a disguise",
+			disguise: nil,
+			want: func(node *pb.FsTreeNode) bool {
+				return !node.GetSentinel() && node.GetLabel() == "c"
+			},
+		},
+		{
+			name:     "disguise shown",
+			disguise: &pb.Disguise{Label: label, Power: &power, Shield: nil, Sentinel: nil},
+			want: func(node *pb.FsTreeNode) bool {
+				return node.GetSentinel() && node.GetLabel() == label && node.GetPower() == power
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+
+			phisher := nodeAction(pb.ActionType_Phisher, bob, 3)
+			phisher.Disguise = test.disguise
+
+			fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+			fix.submit(t, bob, phisher)
+
+			if seen := fix.board(t, alice).GetOpponents()[bob].GetTop().Find(3); !test.want(seen) {
+				t.Errorf("Got node %v shown to the opponent, want it disguised", seen)
+			}
+
+			own := fix.board(t, bob).GetOwn().GetTop().Find(3)
+			if !own.GetSentinel() || own.GetLabel() != "c" {
+				t.Errorf("Got node %v shown to the owner, want it as it is", own)
+			}
+		})
+	}
+}
+
+func TestPhisherMaskHoldsUntilProbed(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+	fix.submit(t, bob, nodeAction(pb.ActionType_Phisher, bob, 3))
+	fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+	// A strike does not probe the node, while a sonar ping does.
+	fix.submit(t, alice, nodeAction(pb.ActionType_Strike, bob, 3))
+
+	if fix.board(t, alice).GetOpponents()[bob].GetTop().Find(3).GetSentinel() {
+		t.Fatal("Got the sentinel shown after a strike, want it still masked")
+	}
+
+	fix.submit(t, alice, sonar(bob))
+
+	if !fix.board(t, alice).GetOpponents()[bob].GetTop().Find(3).GetSentinel() {
+		t.Error("Got the sentinel masked after a sonar ping, want it shown")
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrReplayDiverged = errors.New("game log could not be replayed")
+
+// Replay rebuilds a game from its log by re-applying every entry on top of the seed and the initial trees. Events
+// are delivered to the notifier exactly as they were produced during the original game.
+func Replay(gameLog *pb.GameLog, notify Notifier) (*Game, error) {
+	g := newGame(
+		gameLog.GetId(),
+		gameLog.GetRoomType(),
+		gameLog.GetMode(),
+		int(gameLog.GetRequiredPlayers()),
+		gameLog.GetSeed(),
+		notify,
+		false,
+	)
+
+	err := g.SetMission(gameLog.GetMission())
+	if err != nil {
+		return nil, fmt.Errorf("%w: assigning mission: %w", ErrReplayDiverged, err)
+	}
+
+	for _, id := range gameLog.GetPlayers() {
+		err = g.SetFaction(id, gameLog.GetFactions()[id])
+		if err != nil {
+			return nil, fmt.Errorf("%w: assigning faction of %s: %w", ErrReplayDiverged, id, err)
+		}
+
+		if objective, ok := gameLog.GetObjectives()[id]; ok {
+			err = g.SetObjective(id, objective)
+			if err != nil {
+				return nil, fmt.Errorf("%w: objective of %s: %w", ErrReplayDiverged, id, err)
+			}
+		}
+
+		err = g.AddPlayerState(id, proto.CloneOf(gameLog.GetInitialTrees()[id]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: adding player %s: %w", ErrReplayDiverged, id, err)
+		}
+	}
+
+	for _, entry := range gameLog.GetEntries() {
+		err := g.replayEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %w", ErrReplayDiverged, entry.GetVersion(), err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Game) replayEntry(entry *pb.GameLogEntry) error {
+	switch entry.GetEntry().(type) {
+	case *pb.GameLogEntry_Action:
+		return g.Submit(entry.GetPlayer(), entry.GetAction())
+	case *pb.GameLogEntry_Purchase:
+		return g.Purchase(entry.GetPlayer(), entry.GetPurchase())
+	case *pb.GameLogEntry_Tick:
+		if g.mode != pb.GameMode_RealTime {
+			return ErrInvalidAction
+		}
+
+		g.tick()
+
+		return nil
+	default:
+		return ErrInvalidAction
+	}
+}
+
+// ReplayEvents collects the events of a replayed game as seen by the perspective player. An empty perspective
+// collects every event of the game exactly once.
+func ReplayEvents(gameLog *pb.GameLog, perspective string) ([]*pb.GameEvent, error) {
+	events := []*pb.GameEvent{}
+
+	_, err := Replay(gameLog, func(playerID string, event *pb.GameEvent) {
+		if perspective != "" && playerID != perspective {
+			return
+		}
+
+		if len(events) > 0 && events[len(events)-1].GetSequence() == event.GetSequence() {
+			return
+		}
+
+		events = append(events, event)
+	})
+
+	return events, err
+}
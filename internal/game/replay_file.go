@@ -0,0 +1,119 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ReplayFileExtension = ".nukereplay"
+	ReplayFormatVersion = 1
+)
+
+var (
+	ErrReplayFormat          = errors.New("unsupported replay format")
+	ErrRulesVersionMismatch  = errors.New("replay was recorded with different rules")
+	ErrReplayOutcomeMismatch = errors.New("replayed outcome does not match the recorded outcome")
+)
+
+// NewReplayHeader describes a game for a replay file, holding everything but the entries of its log.
+func NewReplayHeader(g *Game) *pb.ReplayHeader {
+	winner, turns := g.Outcome()
+
+	return &pb.ReplayHeader{
+		FormatVersion: ReplayFormatVersion,
+		Game:          g.Log(),
+		Winner:        winner,
+		Turns:         turns,
+	}
+}
+
+// WriteReplay encodes the header followed by every entry of its game log as length-delimited messages.
+func WriteReplay(w io.Writer, header *pb.ReplayHeader) error {
+	entries := header.GetGame().GetEntries()
+
+	stripped := proto.CloneOf(header)
+	stripped.Game.Entries = nil
+
+	_, err := protodelim.MarshalTo(w, stripped)
+	if err != nil {
+		return fmt.Errorf("could not write replay header: %w", err)
+	}
+
+	for _, entry := range entries {
+		_, err := protodelim.MarshalTo(w, entry)
+		if err != nil {
+			return fmt.Errorf("could not write replay entry %d: %w", entry.GetVersion(), err)
+		}
+	}
+
+	return nil
+}
+
+// ReadReplay decodes a replay file and restores the entries of its game log in the returned header.
+func ReadReplay(r io.Reader) (*pb.ReplayHeader, error) {
+	reader := bufio.NewReader(r)
+	header := &pb.ReplayHeader{}
+
+	err := protodelim.UnmarshalFrom(reader, header)
+	if err != nil {
+		return nil, fmt.Errorf("could not read replay header: %w", err)
+	}
+
+	if header.GetFormatVersion() != ReplayFormatVersion || header.GetGame() == nil {
+		return nil, fmt.Errorf("%w: version %d", ErrReplayFormat, header.GetFormatVersion())
+	}
+
+	for {
+		entry := &pb.GameLogEntry{}
+
+		err := protodelim.UnmarshalFrom(reader, entry)
+		if errors.Is(err, io.EOF) {
+			return header, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read replay entry: %w", err)
+		}
+
+		header.Game.Entries = append(header.Game.Entries, entry)
+	}
+}
+
+// VerifyReplay replays the recorded game with the current rules and checks that it reaches the recorded outcome.
+func VerifyReplay(header *pb.ReplayHeader) error {
+	if header.GetGame().GetRulesVersion() != RulesVersion {
+		return fmt.Errorf(
+			"%w: recorded with %d, current is %d",
+			ErrRulesVersionMismatch,
+			header.GetGame().GetRulesVersion(),
+			RulesVersion,
+		)
+	}
+
+	g, err := Replay(header.GetGame(), func(string, *pb.GameEvent) {})
+	if err != nil {
+		return err
+	}
+
+	winner, turns := g.Outcome()
+
+	if winner != header.GetWinner() || turns != header.GetTurns() {
+		return fmt.Errorf(
+			"%w: replayed winner %q after %d turns, recorded winner %q after %d turns",
+			ErrReplayOutcomeMismatch,
+			winner,
+			turns,
+			header.GetWinner(),
+			header.GetTurns(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReplayFileRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	fix := newBareFixture(t, pb.GameMode_Turns)
+	fix.playOut(t)
+
+	want := NewReplayHeader(fix.game)
+
+	var file bytes.Buffer
+
+	err := WriteReplay(&file, want)
+	if err != nil {
+		t.Fatalf("Could not write replay: %v", err)
+	}
+
+	got, err := ReadReplay(&file)
+	if err != nil {
+		t.Fatalf("Could not read replay: %v", err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("Got replay %v read back, want %v", got, want)
+	}
+
+	if len(want.GetGame().GetEntries()) == 0 {
+		t.Error("Got replay of a game without entries, want the played entries written")
+	}
+}
+
+func TestReadReplayRejectsMalformedFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want error
+		file func(t *testing.T, header *pb.ReplayHeader) []byte
+		name string
+	}{
+		{
+			name: "other format version",
+			want: ErrReplayFormat,
+			file: func(t *testing.T, header *pb.ReplayHeader) []byte {
+				t.Helper()
+
+				header.FormatVersion++
+
+				return encodeReplay(t, header)
+			},
+		},
+		{
+			name: "missing game",
+			want: ErrReplayFormat,
+			file: func(t *testing.T, header *pb.ReplayHeader) []byte {
+				t.Helper()
+
+				header.Game = nil
+
+				var file bytes.Buffer
+
+				_, err := protodelim.MarshalTo(&file, header)
+				if err != nil {
+					t.Fatalf("Could not write replay header: %v", err)
+				}
+
+				return file.Bytes()
+			},
+		},
+		{
+			name: "truncated entry",
+			want: nil,
+			file: func(t *testing.T, header *pb.ReplayHeader) []byte {
+				t.Helper()
+
+				file := encodeReplay(t, header)
+
+				return file[:len(file)-1]
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newBareFixture(t, pb.GameMode_Turns)
+			fix.playOut(t)
+
+			_, err := ReadReplay(bytes.NewReader(test.file(t, NewReplayHeader(fix.game))))
+
+			switch {
+			case err == nil:
+				t.Error("Got the replay read, want it rejected")
+			case test.want != nil && !errors.Is(err, test.want):
+				t.Errorf("Got %v reading the replay, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+// encodeReplay writes the header to a replay file in memory and returns its contents.
+func encodeReplay(t *testing.T, header *pb.ReplayHeader) []byte {
+	t.Helper()
+
+	var file bytes.Buffer
+
+	err := WriteReplay(&file, header)
+	if err != nil {
+		t.Fatalf("Could not write replay: %v", err)
+	}
+
+	return file.Bytes()
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReplayReproducesGame(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []pb.GameMode{pb.GameMode_Turns, pb.GameMode_RealTime} {
+		t.Run(mode.String(), func(t *testing.T) {
+			t.Parallel()
+
+			fix := newBareFixture(t, mode)
+			fix.playOut(t)
+
+			if winner, _ := fix.game.Outcome(); winner != alice {
+				t.Fatalf("Got winner %q, want %q", winner, alice)
+			}
+
+			var file bytes.Buffer
+
+			err := WriteReplay(&file, NewReplayHeader(fix.game))
+			if err != nil {
+				t.Fatalf("Could not write replay: %v", err)
+			}
+
+			header, err := ReadReplay(&file)
+			if err != nil {
+				t.Fatalf("Could not read replay: %v", err)
+			}
+
+			err = VerifyReplay(header)
+			if err != nil {
+				t.Fatalf("Got %v verifying the replay, want it verified", err)
+			}
+
+			// Replaying must deliver the same events to each player, covert ones included.
+			for _, id := range []string{alice, bob} {
+				replayed, err := ReplayEvents(header.GetGame(), id)
+				if err != nil {
+					t.Fatalf("Could not replay events of %s: %v", id, err)
+				}
+
+				want := fix.events(id)
+
+				if len(replayed) != len(want) {
+					t.Fatalf("Got %d events replayed to %s, want %d", len(replayed), id, len(want))
+				}
+
+				for idx, event := range want {
+					if !proto.Equal(replayed[idx], event) {
+						t.Errorf("Got event %v replayed to %s, want %v", replayed[idx], id, event)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyReplayRejectsTamperedReplays(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want   error
+		tamper func(header *pb.ReplayHeader)
+		name   string
+	}{
+		{
+			name:   "other winner",
+			want:   ErrReplayOutcomeMismatch,
+			tamper: func(header *pb.ReplayHeader) { header.Winner = bob },
+		},
+		{
+			name:   "other turn count",
+			want:   ErrReplayOutcomeMismatch,
+			tamper: func(header *pb.ReplayHeader) { header.Turns++ },
+		},
+		{
+			name: "missing entry",
+			want: ErrReplayOutcomeMismatch,
+			tamper: func(header *pb.ReplayHeader) {
+				entries := header.GetGame().GetEntries()
+				header.Game.Entries = entries[:len(entries)-1]
+			},
+		},
+		{
+			name:   "other rules",
+			want:   ErrRulesVersionMismatch,
+			tamper: func(header *pb.ReplayHeader) { header.Game.RulesVersion-- },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newBareFixture(t, pb.GameMode_Turns)
+			fix.playOut(t)
+
+			header := NewReplayHeader(fix.game)
+			test.tamper(header)
+
+			err := VerifyReplay(header)
+			if !errors.Is(err, test.want) {
+				t.Errorf("Got %v verifying the replay, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+// playOut plays the fixture game until alice destroys both sentinels of bob, c first and b next. Bob only strikes the
+// shield of d once, so that the income of alice keeps paying for a strike every turn.
+func (fix *fixture) playOut(t *testing.T) {
+	t.Helper()
+
+	turns := fix.game.mode == pb.GameMode_Turns
+
+	for round, target := range []int32{3, 3, 3, 2, 2, 2} {
+		fix.submit(t, alice, nodeAction(pb.ActionType_Strike, bob, target))
+
+		if turns {
+			if fix.game.Over() {
+				return
+			}
+
+			fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+		}
+
+		if round == 0 {
+			fix.submit(t, bob, nodeAction(pb.ActionType_Strike, alice, 4))
+		}
+
+		if turns {
+			fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+		} else {
+			fix.game.tick()
+		}
+	}
+}
+
+// events returns the events delivered to the player, in the order they were delivered.
+func (fix *fixture) events(playerID string) []*pb.GameEvent {
+	events := []*pb.GameEvent{}
+
+	for _, delivered := range fix.deliveries {
+		if delivered.player == playerID {
+			events = append(events, delivered.event)
+		}
+	}
+
+	return events
+}
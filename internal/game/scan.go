@@ -0,0 +1,198 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	HealthScanCost     = 10
+	HealthScanCooldown = 2
+	// HealthScanReach is the number of nodes a single health scan checks.
+	HealthScanReach  = 12
+	DeepScanCost     = 20
+	DeepScanCooldown = 4
+	// DeepScanReach is the most nodes a single deep scan passes through.
+	DeepScanReach = 8
+	// DeepScanStepsPerTurn is the number of nodes a deep scan passes through on every turn of the caster.
+	DeepScanStepsPerTurn = 2
+)
+
+// deepScan is a deep scan in progress. The path holds the nodes it has yet to pass through, and from is the node it
+// last passed through.
+type deepScan struct {
+	caster string
+	owner  string
+	path   []int32
+	from   int32
+	step   int32
+}
+
+// applyHealthScan checks the tree of the caster breadth-first from the targeted node for effects planted by
+// opponents, uncovering hidden ones such as backdoors and infestations. The caster receives the compromised nodes
+// along with their effects, and a prompt for every backdoor found.
+func applyHealthScan(g *Game, playerID string, action *pb.Action) outcome {
+	player := g.players[playerID]
+	scanned := []*pb.FsTreeNode{}
+
+	target := player.Tree.GetTop().Find(action.GetTargetNode())
+	if target == nil {
+		return outcome{changes: nil, private: nil, targeted: nil}
+	}
+
+	queue := []*pb.FsTreeNode{target}
+
+	for len(queue) > 0 && len(scanned) < HealthScanReach {
+		node := queue[0]
+		queue = append(queue[1:], node.GetChildren()...)
+
+		scanned = append(scanned, node)
+	}
+
+	private := player.detect(scanned)
+	compromised := []*pb.FsTreeNode{}
+
+	for _, node := range scanned {
+		planted := slices.ContainsFunc(player.effects, func(applied *effect) bool {
+			return applied.node == node.GetId() && applied.source != playerID
+		})
+
+		if !planted {
+			continue
+		}
+
+		shallow := proto.CloneOf(node)
+		shallow.Children = []*pb.FsTreeNode{}
+		player.describeEffects(&pb.FsTree{Top: shallow}, playerID)
+
+		compromised = append(compromised, shallow)
+	}
+
+	private = append([]pb.GameEventPayload{&pb.GameEvent_ScanResult{
+		ScanResult: &pb.ScanResult{TargetPlayer: playerID, Nodes: compromised},
+	}}, private...)
+
+	return outcome{changes: nil, private: private, targeted: nil}
+}
+
+// targetScanPath accepts scan paths through standing nodes of an opponent, even obscured ones. The path is made of
+// the target nodes of the action, or of the targeted node alone, and must pass through the targeted node. Every node
+// of the path is a parent or child of the node before it, and the path is at most DeepScanReach nodes long.
+func targetScanPath(g *Game, playerID string, action *pb.Action) error {
+	_, err := g.opponentNode(playerID, action)
+	if err != nil {
+		return err
+	}
+
+	path := scanPath(action)
+	if len(path) > DeepScanReach || !slices.Contains(path, action.GetTargetNode()) {
+		return ErrInvalidAction
+	}
+
+	top := g.players[action.GetTargetPlayer()].Tree.GetTop()
+
+	for idx, id := range path {
+		node := top.Find(id)
+		if node == nil || node.GetDestroyed() {
+			return ErrInvalidAction
+		}
+
+		if idx > 0 && !adjacent(top.Find(path[idx-1]), node) {
+			return ErrInvalidAction
+		}
+	}
+
+	return nil
+}
+
+// applyDeepScan starts a scan along the path chosen by the caster through the tree of the opponent. The scan advances
+// DeepScanStepsPerTurn nodes right away and as many again on every following turn of the caster. Every node the scan
+// passes through is probed, which breaks its mask, and the caster receives the traversal one step at a time, each
+// along the edge from the node it was entered from, so that its path can be traced. The first step comes from the
+// first node of the path itself.
+func applyDeepScan(g *Game, playerID string, action *pb.Action) outcome {
+	path := scanPath(action)
+	scan := &deepScan{
+		caster: playerID,
+		owner:  action.GetTargetPlayer(),
+		path:   path,
+		from:   path[0],
+		step:   0,
+	}
+
+	steps := g.advanceScan(scan)
+
+	if len(scan.path) > 0 {
+		g.scans = append(g.scans, scan)
+	}
+
+	return outcome{changes: nil, private: steps, targeted: nil}
+}
+
+// advanceScans advances the deep scans of the player by one of their turns, and drops the scans that are done.
+func (g *Game) advanceScans(player *Player) {
+	for _, scan := range g.scans {
+		if scan.caster != player.ID {
+			continue
+		}
+
+		for _, step := range g.advanceScan(scan) {
+			g.send(player.ID, step)
+		}
+	}
+
+	g.scans = slices.DeleteFunc(g.scans, func(scan *deepScan) bool {
+		return len(scan.path) == 0
+	})
+}
+
+// advanceScan probes the next nodes of the path of the scan and returns its steps. A scan that reaches a node which
+// has fallen or was cut off the tree in the meantime stops there.
+func (g *Game) advanceScan(scan *deepScan) []pb.GameEventPayload {
+	owner := g.players[scan.owner]
+	steps := []pb.GameEventPayload{}
+
+	for range DeepScanStepsPerTurn {
+		if len(scan.path) == 0 {
+			break
+		}
+
+		node := owner.Tree.GetTop().Find(scan.path[0])
+		if node == nil || node.GetDestroyed() {
+			scan.path = nil
+			break
+		}
+
+		owner.unmask(node.GetId())
+
+		if node.GetVisibility() < pb.Visibility_Probed {
+			node.Visibility = probedVisibility(node.GetVisibility())
+		}
+
+		steps = append(steps, &pb.GameEvent_ScanStep{ScanStep: &pb.ScanStep{
+			TargetPlayer: owner.ID,
+			Step:         scan.step,
+			From:         scan.from,
+			Node:         projectShallow(node, owner.disguises()),
+		}})
+
+		scan.from, scan.path, scan.step = node.GetId(), scan.path[1:], scan.step+1
+	}
+
+	return steps
+}
+
+func scanPath(action *pb.Action) []int32 {
+	if len(action.GetTargetNodes()) == 0 {
+		return []int32{action.GetTargetNode()}
+	}
+
+	return action.GetTargetNodes()
+}
+
+// adjacent reports whether one of the nodes is a child of the other.
+func adjacent(a, b *pb.FsTreeNode) bool {
+	return slices.Contains(a.GetChildren(), b) || slices.Contains(b.GetChildren(), a)
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestDeepScanFollowsChosenPathOverTurns(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+
+	scan := nodeAction(pb.ActionType_DeepScan, bob, 1)
+	scan.TargetNodes = []int32{0, 1, 2, 1, 0, 4}
+
+	fix.submit(t, alice, scan)
+
+	// Every turn of alice advances the scan, while the turns of bob leave it be.
+	want := [][2]int32{{0, 0}, {0, 1}}
+	if got := fix.scanSteps(); !slices.Equal(got, want) {
+		t.Fatalf("Got steps %v on the scan, want %v", got, want)
+	}
+
+	for range 2 {
+		fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+		fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+	}
+
+	want = append(want, [2]int32{1, 2}, [2]int32{2, 1}, [2]int32{1, 0}, [2]int32{0, 4})
+	if got := fix.scanSteps(); !slices.Equal(got, want) {
+		t.Fatalf("Got steps %v after two turns, want %v", got, want)
+	}
+
+	if len(fix.game.scans) != 0 {
+		t.Errorf("Got %d scans in progress, want none once the path is done", len(fix.game.scans))
+	}
+}
+
+func TestDeepScanRejectsBrokenPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		nodes []int32
+	}{
+		{name: "skips an edge", nodes: []int32{1, 3}},
+		{name: "misses the target", nodes: []int32{0, 3}},
+		{name: "unknown node", nodes: []int32{1, 42}},
+		{name: "too long", nodes: []int32{1, 0, 1, 0, 1, 0, 1, 0, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+
+			scan := nodeAction(pb.ActionType_DeepScan, bob, 1)
+			scan.TargetNodes = test.nodes
+
+			err := fix.game.Submit(alice, scan)
+			if err == nil {
+				t.Errorf("Got scan along %v accepted, want it rejected", test.nodes)
+			}
+		})
+	}
+}
+
+// scanSteps returns the edges of the scan steps delivered to alice, from the node each step was entered from to the
+// node it passed through.
+func (fix *fixture) scanSteps() [][2]int32 {
+	steps := [][2]int32{}
+
+	for _, delivered := range fix.deliveries {
+		step := delivered.event.GetScanStep()
+		if delivered.player == alice && step != nil {
+			steps = append(steps, [2]int32{step.GetFrom(), step.GetNode().GetId()})
+		}
+	}
+
+	return steps
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	ShieldBoostAmount   = 15
+	RechargeBoostAmount = 2
+)
+
+var ErrAbilityLocked = errors.New("ability has not been unlocked")
+
+type (
+	// upgrade describes goods in the catalog of the shop. Boosts improve a node of the buyer, while unlocks grant
+	// access to an ability that is locked until bought. Goods are listed at a price in every market they are offered
+	// in, and buying them on the black market carries the risk of the buyer getting caught.
+	upgrade struct {
+		boost   func(node *pb.FsTreeNode)
+		prices  map[pb.Market]int32
+		unlocks pb.ActionType
+		risk    int32
+	}
+)
+
+//nolint:gochecknoglobals,mnd // Upgrade catalog is static.
+var upgrades = map[pb.UpgradeType]upgrade{
+	pb.UpgradeType_ShieldBoost: {
+		boost:   boostShield,
+		prices:  map[pb.Market]int32{pb.Market_Corporate: 20, pb.Market_BlackMarket: 14},
+		unlocks: pb.ActionType_EndTurn,
+		risk:    15,
+	},
+	pb.UpgradeType_RechargeBoost: {
+		boost:   boostRecharge,
+		prices:  map[pb.Market]int32{pb.Market_Corporate: 25, pb.Market_BlackMarket: 18},
+		unlocks: pb.ActionType_EndTurn,
+		risk:    15,
+	},
+	pb.UpgradeType_NukeUnlock: {
+		boost:   nil,
+		prices:  map[pb.Market]int32{pb.Market_Corporate: 60, pb.Market_BlackMarket: 45},
+		unlocks: pb.ActionType_Nuke,
+		risk:    25,
+	},
+	pb.UpgradeType_DDoSUnlock: {
+		boost:   nil,
+		prices:  map[pb.Market]int32{pb.Market_Corporate: 40, pb.Market_BlackMarket: 28},
+		unlocks: pb.ActionType_DDoS,
+		risk:    25,
+	},
+	pb.UpgradeType_SniperUnlock: {
+		boost:   nil,
+		prices:  map[pb.Market]int32{pb.Market_Corporate: 50, pb.Market_BlackMarket: 35},
+		unlocks: pb.ActionType_Sniper,
+		risk:    25,
+	},
+	// Backdoors are not sold by corporations.
+	pb.UpgradeType_BackdoorUnlock: {
+		boost:   nil,
+		prices:  map[pb.Market]int32{pb.Market_BlackMarket: 40},
+		unlocks: pb.ActionType_Backdoor,
+		risk:    25,
+	},
+}
+
+// Purchase buys an upgrade with the budget of the player. In turn mode upgrades can only be bought on the player's
+// turn. Corporate purchases are announced to every player, while black-market purchases are only announced to the
+// buyer unless they get caught.
+func (g *Game) Purchase(playerID string, purchase *pb.Purchase) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	player, err := g.actor(playerID)
+	if err != nil {
+		return err
+	}
+
+	if g.mode == pb.GameMode_Turns && g.order[g.current] != playerID {
+		return ErrNotYourTurn
+	}
+
+	err = g.buy(player, purchase)
+	if err != nil {
+		return err
+	}
+
+	g.record(playerID, &pb.GameLogEntry_Purchase{Purchase: purchase})
+
+	return nil
+}
+
+// buy validates the purchase against the catalog, deducts its price for the faction of the player and grants the
+// upgrade.
+func (g *Game) buy(player *Player, purchase *pb.Purchase) error {
+	spec, ok := upgrades[purchase.GetUpgrade()]
+	if !ok {
+		return ErrInvalidAction
+	}
+
+	list, ok := spec.prices[purchase.GetMarket()]
+	if !ok {
+		return ErrInvalidAction
+	}
+
+	var node *pb.FsTreeNode
+
+	if spec.boost != nil {
+		node = player.Tree.GetTop().Find(purchase.GetNode())
+		if node == nil || node.GetDestroyed() {
+			return ErrInvalidAction
+		}
+	} else if player.unlocked[spec.unlocks] {
+		return ErrInvalidAction
+	}
+
+	price := player.price(list, purchase.GetMarket())
+	if player.Budget < price {
+		return ErrInsufficientPower
+	}
+
+	player.Budget -= price
+
+	if node != nil {
+		spec.boost(node)
+	} else {
+		player.unlocked[spec.unlocks] = true
+	}
+
+	detected := g.caught(player, purchase.GetMarket(), spec.risk)
+
+	purchased := &pb.GameEvent_Purchased{
+		Purchased: &pb.Purchased{Player: player.ID, Purchase: purchase, Detected: detected},
+	}
+
+	if purchase.GetMarket() == pb.Market_BlackMarket && !detected {
+		g.send(player.ID, purchased)
+	} else {
+		g.broadcast(purchased)
+	}
+
+	return nil
+}
+
+// locked reports whether the ability is sold in the shop and has not been unlocked by the player yet.
+func (player *Player) locked(actionType pb.ActionType) bool {
+	for _, spec := range upgrades {
+		if spec.boost == nil && spec.unlocks == actionType {
+			return !player.unlocked[actionType]
+		}
+	}
+
+	return false
+}
+
+// offers lists the catalog at the prices of the player's faction, leaving out abilities the player has unlocked.
+func (player *Player) offers() []*pb.Offer {
+	offers := []*pb.Offer{}
+
+	for _, upgradeType := range slices.Sorted(maps.Keys(upgrades)) {
+		spec := upgrades[upgradeType]
+		if spec.boost == nil && player.unlocked[spec.unlocks] {
+			continue
+		}
+
+		for _, source := range slices.Sorted(maps.Keys(spec.prices)) {
+			offers = append(offers, &pb.Offer{
+				Upgrade: upgradeType,
+				Market:  source,
+				Price:   player.price(spec.prices[source], source),
+			})
+		}
+	}
+
+	return offers
+}
+
+func boostShield(node *pb.FsTreeNode) {
+	node.MaxShield += ShieldBoostAmount
+	node.Shield += ShieldBoostAmount
+}
+
+func boostRecharge(node *pb.FsTreeNode) {
+	node.RechargeRate += RechargeBoostAmount
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestPurchaseChargesFactionPrice(t *testing.T) {
+	t.Parallel()
+
+	// The Nuke unlock is listed at 60 by corporations and at 45 on the black market.
+	tests := []struct {
+		name    string
+		faction pb.Faction
+		market  pb.Market
+		want    int32
+	}{
+		{
+			name:    "rogue corporate",
+			faction: pb.Faction_Rogue,
+			market:  pb.Market_Corporate,
+			want:    60,
+		},
+		{
+			name:    "rogue black market",
+			faction: pb.Faction_Rogue,
+			market:  pb.Market_BlackMarket,
+			want:    31,
+		},
+		{
+			name:    "ceo corporate",
+			faction: pb.Faction_CEO,
+			market:  pb.Market_Corporate,
+			want:    48,
+		},
+		{
+			name:    "ceo black market",
+			faction: pb.Faction_CEO,
+			market:  pb.Market_BlackMarket,
+			want:    58,
+		},
+		{
+			name:    "salary-man corporate",
+			faction: pb.Faction_SalaryMan,
+			market:  pb.Market_Corporate,
+			want:    60,
+		},
+		{
+			name:    "salary-man black market",
+			faction: pb.Faction_SalaryMan,
+			market:  pb.Market_BlackMarket,
+			want:    45,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_RealTime)
+			player := fix.game.players[alice]
+			player.Faction = test.faction
+
+			for _, offer := range player.offers() {
+				nuke := offer.GetUpgrade() == pb.UpgradeType_NukeUnlock
+				if nuke && offer.GetMarket() == test.market && offer.GetPrice() != test.want {
+					t.Errorf("Got offer at %d, want %d", offer.GetPrice(), test.want)
+				}
+			}
+
+			err := fix.game.Purchase(alice, purchase(pb.UpgradeType_NukeUnlock, test.market, 0))
+			if err != nil {
+				t.Fatalf("Could not buy the unlock: %v", err)
+			}
+
+			if got := fixtureBudget - player.Budget; got != test.want {
+				t.Errorf("Got %d charged, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPurchaseUnlocksAbility(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	nuke := nodeAction(pb.ActionType_Nuke, bob, 4)
+
+	err := fix.game.Submit(alice, nuke)
+	if !errors.Is(err, ErrAbilityLocked) {
+		t.Fatalf("Got %v using a locked Nuke, want %v", err, ErrAbilityLocked)
+	}
+
+	err = fix.game.Purchase(alice, purchase(pb.UpgradeType_NukeUnlock, pb.Market_Corporate, 0))
+	if err != nil {
+		t.Fatalf("Could not buy the unlock: %v", err)
+	}
+
+	for _, offer := range fix.game.players[alice].offers() {
+		if offer.GetUpgrade() == pb.UpgradeType_NukeUnlock {
+			t.Errorf("Got unlock offered at %d once bought, want it left out", offer.GetPrice())
+		}
+	}
+
+	err = fix.game.Purchase(alice, purchase(pb.UpgradeType_NukeUnlock, pb.Market_Corporate, 0))
+	if !errors.Is(err, ErrInvalidAction) {
+		t.Errorf("Got %v buying the unlock again, want %v", err, ErrInvalidAction)
+	}
+
+	fix.submit(t, alice, nuke)
+
+	// The unlock is bought by alice alone.
+	err = fix.game.Submit(bob, nodeAction(pb.ActionType_Nuke, alice, 4))
+	if !errors.Is(err, ErrAbilityLocked) {
+		t.Errorf("Got %v using Nuke without the unlock, want %v", err, ErrAbilityLocked)
+	}
+}
+
+func TestPurchaseRejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want     error
+		purchase *pb.Purchase
+		name     string
+		budget   int32
+	}{
+		{
+			name:     "not sold in the market",
+			purchase: purchase(pb.UpgradeType_BackdoorUnlock, pb.Market_Corporate, 0),
+			budget:   fixtureBudget,
+			want:     ErrInvalidAction,
+		},
+		{
+			name:     "boost on an unknown node",
+			purchase: purchase(pb.UpgradeType_ShieldBoost, pb.Market_Corporate, 42),
+			budget:   fixtureBudget,
+			want:     ErrInvalidAction,
+		},
+		{
+			name:     "over budget",
+			purchase: purchase(pb.UpgradeType_NukeUnlock, pb.Market_Corporate, 0),
+			budget:   59,
+			want:     ErrInsufficientPower,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_RealTime)
+			fix.game.players[alice].Budget = test.budget
+
+			err := fix.game.Purchase(alice, test.purchase)
+			if !errors.Is(err, test.want) {
+				t.Errorf("Got %v, want %v", err, test.want)
+			}
+
+			if got := fix.game.players[alice].Budget; got != test.budget {
+				t.Errorf("Got budget %d after the rejected purchase, want %d", got, test.budget)
+			}
+		})
+	}
+}
+
+// purchase builds a purchase of the upgrade in the market, boosting the node for upgrades that boost one.
+func purchase(upgrade pb.UpgradeType, market pb.Market, node int32) *pb.Purchase {
+	return &pb.Purchase{Upgrade: upgrade, Market: market, Node: node}
+}
@@ -0,0 +1,379 @@
+package game
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	//nolint:depguard // Simulations are seeded for reproducible statistics and carry no security weight.
+	"math/rand/v2"
+	"slices"
+	"strconv"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	DefaultSimulationMaxTurns = 500
+
+	// maxSimulatedActions bounds the actions of a player on a single turn or tick.
+	maxSimulatedActions = 64
+)
+
+type (
+	// SimulationOptions configures a batch of bot-vs-bot games. Rating overrides replace the ratings of every
+	// generated node when set, and cost overrides replace the list prices of abilities, so that balance changes can be
+	// compared before touching the defaults. Bots use the simulated abilities, unlocked from the start, on the targets
+	// picked by their strategy before striking them. Adaptive bots adapt at the adaptation rate, or at the default
+	// rate when it is 0.
+	SimulationOptions struct {
+		Costs          map[pb.ActionType]int32
+		Abilities      []pb.ActionType
+		TreeOpts       TreeGenOptions
+		Seed           uint64
+		Games          int
+		AdaptationRate float64
+		Difficulties   [2]pb.BotDifficulty
+		Mode           pb.GameMode
+		MaxTurns       int32
+		Power          int32
+		Shield         int32
+		RechargeRate   int32
+	}
+
+	// SimulationReport aggregates the outcomes of a batch of simulated games.
+	SimulationReport struct {
+		ActionUsage        map[string]int `json:"actionUsage"`
+		Games              int            `json:"games"`
+		FirstPlayerWins    int            `json:"firstPlayerWins"`
+		SecondPlayerWins   int            `json:"secondPlayerWins"`
+		Draws              int            `json:"draws"`
+		Unfinished         int            `json:"unfinished"`
+		FirstPlayerWinRate float64        `json:"firstPlayerWinRate"`
+		AverageTurns       float64        `json:"averageTurns"`
+	}
+
+	simulatedPlayer struct {
+		strategy  Strategy
+		adaptive  *Adaptive
+		rng       *rand.Rand
+		id        string
+		abilities []pb.ActionType
+	}
+)
+
+// Simulate plays the configured number of games between two bots without a server. Every game is derived from the
+// seed of the options, so the same options always produce the same report.
+func Simulate(opts SimulationOptions) *SimulationReport {
+	report := &SimulationReport{
+		ActionUsage:        map[string]int{},
+		Games:              opts.Games,
+		FirstPlayerWins:    0,
+		SecondPlayerWins:   0,
+		Draws:              0,
+		Unfinished:         0,
+		FirstPlayerWinRate: 0,
+		AverageTurns:       0,
+	}
+
+	maxTurns := opts.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultSimulationMaxTurns
+	}
+
+	var totalTurns int64
+
+	for idx := range opts.Games {
+		//nolint:gosec // Game indices are bounded by the options.
+		g := simulateGame(opts, opts.Seed+uint64(idx), maxTurns, report.ActionUsage)
+
+		winner, turns := g.Outcome()
+		totalTurns += int64(turns)
+
+		switch {
+		case !g.Over():
+			report.Unfinished++
+		case winner == "":
+			report.Draws++
+		case winner == g.order[0]:
+			report.FirstPlayerWins++
+		default:
+			report.SecondPlayerWins++
+		}
+	}
+
+	if opts.Games > 0 {
+		report.FirstPlayerWinRate = float64(report.FirstPlayerWins) / float64(opts.Games)
+		report.AverageTurns = float64(totalTurns) / float64(opts.Games)
+	}
+
+	return report
+}
+
+func simulateGame(opts SimulationOptions, seed uint64, maxTurns int32, usage map[string]int) *Game {
+	//nolint:gosec // Simulations carry no security weight.
+	rng := rand.New(rand.NewPCG(seed, seed))
+	adaptives := map[string]*Adaptive{}
+
+	g := newGame(
+		fmt.Sprintf("SIM-%d", seed),
+		pb.RoomType_Regular,
+		opts.Mode,
+		len(opts.Difficulties),
+		seed,
+		func(playerID string, event *pb.GameEvent) {
+			// Count every resolved action once, from the perspective of the acting player.
+			resolved := event.GetActionResolved()
+			if resolved != nil && resolved.GetPlayer() == playerID {
+				usage[resolved.GetAction().GetType().String()]++
+			}
+
+			if adaptive, ok := adaptives[playerID]; ok {
+				adaptive.Observe(event)
+			}
+		},
+		false,
+	)
+
+	maps.Copy(g.costs, opts.Costs)
+
+	players := make([]simulatedPlayer, 0, len(opts.Difficulties))
+
+	for idx, difficulty := range opts.Difficulties {
+		player := simulatedPlayer{
+			strategy: NewStrategy(difficulty, rng.Uint64()),
+			adaptive: nil,
+			//nolint:gosec // Seeded apart from the game, so that simulations without abilities are unaffected.
+			rng:       rand.New(rand.NewPCG(seed, uint64(idx))),
+			id:        fmt.Sprintf("P%d", idx+1),
+			abilities: opts.Abilities,
+		}
+
+		if difficulty == pb.BotDifficulty_Adaptive {
+			player.adaptive = NewAdaptive(player.id, opts.AdaptationRate, rng.Uint64())
+			player.strategy = player.adaptive.Act
+			adaptives[player.id] = player.adaptive
+
+			err := g.SetFaction(player.id, AdaptiveFaction)
+			if err != nil {
+				log.Panicf("Simulated player could not pick a faction: %v", err)
+			}
+		}
+
+		tree := NewBotTree(rng.Uint64(), opts.TreeOpts)
+		overrideRatings(&tree, opts)
+
+		err := g.AddPlayerState(player.id, &tree)
+		if err != nil {
+			log.Panicf("Simulated player could not join: %v", err)
+		}
+
+		for _, ability := range opts.Abilities {
+			g.players[player.id].unlocked[ability] = true
+		}
+
+		players = append(players, player)
+	}
+
+	endTurn := &pb.Action{
+		Type:         pb.ActionType_EndTurn,
+		TargetPlayer: "",
+		TargetNode:   0,
+		TargetNodes:  nil,
+		Disguise:     nil,
+		OwnNode:      0,
+		FramedPlayer: "",
+		TargetPath:   nil,
+	}
+
+	for !g.Over() && g.turn < maxTurns {
+		switch opts.Mode {
+		case pb.GameMode_Turns:
+			current := players[slices.IndexFunc(players, func(player simulatedPlayer) bool {
+				return player.id == g.order[g.current]
+			})]
+
+			current.play(g)
+
+			err := g.Submit(current.id, endTurn)
+			if err != nil {
+				return g
+			}
+		case pb.GameMode_RealTime:
+			for _, player := range players {
+				player.play(g)
+			}
+
+			g.tick()
+		}
+	}
+
+	return g
+}
+
+// play buys the counter of adaptive players and submits the actions picked by the strategy of the player until it
+// yields or an action or purchase is rejected. Strikes are replaced by one of the simulated abilities whenever one of
+// them is accepted.
+func (player simulatedPlayer) play(g *Game) {
+	if player.adaptive != nil && player.counter(g) != nil {
+		return
+	}
+
+	for range maxSimulatedActions {
+		board, err := g.Board(player.id)
+		if err != nil {
+			return
+		}
+
+		action := player.strategy(board)
+		if action.GetType() == pb.ActionType_EndTurn {
+			return
+		}
+
+		if action.GetType() == pb.ActionType_Strike && player.useAbility(g, board, action) {
+			continue
+		}
+
+		if g.Submit(player.id, action) != nil {
+			return
+		}
+	}
+}
+
+// useAbility submits one of the simulated abilities, tried in random order, and reports whether one was accepted.
+func (player simulatedPlayer) useAbility(g *Game, board *pb.Board, strike *pb.Action) bool {
+	for _, idx := range player.rng.Perm(len(player.abilities)) {
+		for _, action := range aim(board, player.id, player.abilities[idx], strike) {
+			if g.Submit(player.id, action) == nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// aim aims the ability at the target of the strike, and at the weakest node of the player for abilities that
+// support the own tree instead. Every target field is filled in, so that each ability finds what it needs: the
+// cluster of the target, its label path and the top of the player to siphon into.
+func aim(board *pb.Board, playerID string, ability pb.ActionType, strike *pb.Action) []*pb.Action {
+	top := board.GetOpponents()[strike.GetTargetPlayer()].GetTop()
+	path := top.Path(strike.GetTargetNode())
+	labels := make([]string, 0, len(path))
+
+	for _, node := range path[min(1, len(path)):] {
+		labels = append(labels, node.GetLabel())
+	}
+
+	own := board.GetOwn().GetTop()
+	weakest := own
+
+	own.Walk(func(node *pb.FsTreeNode) {
+		if node == own || node.GetDestroyed() {
+			return
+		}
+
+		if weakest == own || durability(node) < durability(weakest) {
+			weakest = node
+		}
+	})
+
+	return []*pb.Action{
+		{
+			Type:         ability,
+			TargetPlayer: strike.GetTargetPlayer(),
+			TargetNode:   strike.GetTargetNode(),
+			TargetNodes:  []int32{strike.GetTargetNode()},
+			Disguise:     nil,
+			OwnNode:      own.GetId(),
+			FramedPlayer: "",
+			TargetPath:   labels,
+		},
+		{
+			Type:         ability,
+			TargetPlayer: playerID,
+			TargetNode:   weakest.GetId(),
+			TargetNodes:  nil,
+			Disguise:     nil,
+			OwnNode:      own.GetId(),
+			FramedPlayer: "",
+			TargetPath:   nil,
+		},
+	}
+}
+
+// counter buys the upgrade the adaptive player counters the plays of its opponents with, if any.
+func (player simulatedPlayer) counter(g *Game) error {
+	board, err := g.Board(player.id)
+	if err != nil {
+		return err
+	}
+
+	purchase := player.adaptive.Counter(board)
+	if purchase == nil {
+		return nil
+	}
+
+	return g.Purchase(player.id, purchase)
+}
+
+func overrideRatings(tree *pb.FsTree, opts SimulationOptions) {
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if opts.Power > 0 {
+			node.Power = opts.Power
+		}
+
+		if opts.Shield > 0 {
+			node.Shield, node.MaxShield = opts.Shield, opts.Shield
+		}
+
+		if opts.RechargeRate > 0 {
+			node.RechargeRate = opts.RechargeRate
+		}
+	})
+}
+
+// WriteJSON encodes the report as a single JSON object.
+func (report *SimulationReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(report)
+	if err != nil {
+		return fmt.Errorf("could not encode simulation report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV encodes the report as metric and value rows. Action usage is listed per action type.
+func (report *SimulationReport) WriteCSV(w io.Writer) error {
+	rows := [][]string{
+		{"metric", "value"},
+		{"games", strconv.Itoa(report.Games)},
+		{"firstPlayerWins", strconv.Itoa(report.FirstPlayerWins)},
+		{"secondPlayerWins", strconv.Itoa(report.SecondPlayerWins)},
+		{"draws", strconv.Itoa(report.Draws)},
+		{"unfinished", strconv.Itoa(report.Unfinished)},
+		{"firstPlayerWinRate", strconv.FormatFloat(report.FirstPlayerWinRate, 'f', -1, 64)},
+		{"averageTurns", strconv.FormatFloat(report.AverageTurns, 'f', -1, 64)},
+	}
+
+	for _, actionType := range slices.Sorted(maps.Keys(report.ActionUsage)) {
+		rows = append(
+			rows,
+			[]string{"actionUsage." + actionType, strconv.Itoa(report.ActionUsage[actionType])},
+		)
+	}
+
+	writer := csv.NewWriter(w)
+
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return fmt.Errorf("could not encode simulation report: %w", err)
+	}
+
+	return nil
+}
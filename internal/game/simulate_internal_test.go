@@ -0,0 +1,93 @@
+package game
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestSimulateIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []pb.GameMode{pb.GameMode_Turns, pb.GameMode_RealTime} {
+		t.Run(mode.String(), func(t *testing.T) {
+			t.Parallel()
+
+			opts := simulationOptions(mode)
+
+			report := Simulate(opts)
+			if again := Simulate(opts); !reflect.DeepEqual(report, again) {
+				t.Errorf("Got report %+v simulated again, want %+v", again, report)
+			}
+
+			wins := report.FirstPlayerWins + report.SecondPlayerWins
+			if outcomes := wins + report.Draws + report.Unfinished; outcomes != opts.Games {
+				t.Errorf("Got %d outcomes, want one for each of the %d games", outcomes, opts.Games)
+			}
+
+			if report.ActionUsage[pb.ActionType_Strike.String()] == 0 {
+				t.Error("Got no strikes used, want the bots to strike")
+			}
+		})
+	}
+}
+
+func TestSimulateHonorsOverrides(t *testing.T) {
+	t.Parallel()
+
+	opts := simulationOptions(pb.GameMode_Turns)
+	opts.MaxTurns = 4
+	opts.Costs = map[pb.ActionType]int32{pb.ActionType_Strike: 1000}
+
+	report := Simulate(opts)
+
+	if report.Unfinished != opts.Games {
+		t.Errorf("Got %d of %d games unfinished, want all of them", report.Unfinished, opts.Games)
+	}
+
+	if got := report.ActionUsage[pb.ActionType_Strike.String()]; got != 0 {
+		t.Errorf("Got %d strikes used at an unaffordable cost, want none", got)
+	}
+
+	if report.AverageTurns != float64(opts.MaxTurns) {
+		t.Errorf("Got %f turns on average, want the cap of %d", report.AverageTurns, opts.MaxTurns)
+	}
+}
+
+func TestSimulationReportCSV(t *testing.T) {
+	t.Parallel()
+
+	report := Simulate(simulationOptions(pb.GameMode_Turns))
+
+	var file bytes.Buffer
+
+	err := report.WriteCSV(&file)
+	if err != nil {
+		t.Fatalf("Could not write the report: %v", err)
+	}
+
+	if !strings.Contains(file.String(), "\ngames,3\n") {
+		t.Errorf("Got report %q, want a row of 3 games", file.String())
+	}
+}
+
+// simulationOptions configures a short batch of games of the mode between a medium and a hard bot.
+func simulationOptions(mode pb.GameMode) SimulationOptions {
+	return SimulationOptions{
+		Costs:          nil,
+		Abilities:      nil,
+		TreeOpts:       TreeGenOptions{Ignore: nil, VisibilityDepth: 3, Depth: 2, Width: 3},
+		Seed:           fixtureSeed,
+		Games:          3,
+		AdaptationRate: 0,
+		Difficulties:   [2]pb.BotDifficulty{pb.BotDifficulty_Medium, pb.BotDifficulty_Hard},
+		Mode:           mode,
+		MaxTurns:       0,
+		Power:          0,
+		Shield:         0,
+		RechargeRate:   0,
+	}
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	SiphonCost     = 15
+	SiphonCooldown = 3
+	// SiphonDuration is the number of turns of the owner a siphon keeps draining the targeted node.
+	SiphonDuration = 3
+	// SiphonRate is the most power a siphon transfers on every turn of the owner.
+	SiphonRate = 5
+)
+
+// targetSiphon accepts visible nodes of opponents, siphoned into a standing node of the caster.
+func targetSiphon(g *Game, playerID string, action *pb.Action) error {
+	err := targetVisibleNode(g, playerID, action)
+	if err != nil {
+		return err
+	}
+
+	return g.ownNode(playerID, action.GetOwnNode())
+}
+
+// applySiphon attaches a siphon to the targeted node, which transfers its power to the own node of the caster over
+// the following turns of the owner.
+func applySiphon(g *Game, playerID string, action *pb.Action) outcome {
+	g.players[action.GetTargetPlayer()].applyEffect(&effect{
+		disguise:  nil,
+		source:    playerID,
+		kind:      pb.StatusEffectType_PowerSiphon,
+		node:      action.GetTargetNode(),
+		recipient: action.GetOwnNode(),
+		dormant:   0,
+		remaining: SiphonDuration,
+		stacks:    0,
+		detected:  false,
+	})
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+// siphonPower moves power from the siphoned node to the receiving node of the source. Siphoning never drains a node
+// below one power, and stops once the receiving node has fallen.
+func siphonPower(g *Game, player *Player, applied *effect) {
+	source, ok := g.players[applied.source]
+	if !ok || source.Eliminated {
+		return
+	}
+
+	recipient := source.Tree.GetTop().Find(applied.recipient)
+	if recipient == nil || recipient.GetDestroyed() {
+		return
+	}
+
+	node := player.Tree.GetTop().Find(applied.node)
+	if node == nil {
+		return
+	}
+
+	siphoned := max(min(SiphonRate, node.GetPower()-1), 0)
+
+	node.Power -= siphoned
+	recipient.Power += siphoned
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestSiphonsOfDifferentCastersCoexist(t *testing.T) {
+	t.Parallel()
+
+	fix := newTrioFixture(t, pb.GameMode_RealTime)
+
+	for _, id := range []string{bob, carol} {
+		siphon := nodeAction(pb.ActionType_Siphon, alice, 4)
+		siphon.OwnNode = 4
+
+		fix.submit(t, id, siphon)
+	}
+
+	// Both siphons drain the node right away on the tick that follows.
+	fix.game.tick()
+
+	for _, id := range []string{bob, carol} {
+		recipient := fix.game.players[id].Tree.GetTop().Find(4)
+		if got, want := recipient.GetPower(), int32(DefaultPower+SiphonRate); got != want {
+			t.Errorf("Got power %d siphoned into the node of %s, want %d", got, id, want)
+		}
+	}
+
+	drained := fix.game.players[alice].Tree.GetTop().Find(4)
+	if got, want := drained.GetPower(), int32(DefaultPower-2*SiphonRate); got != want {
+		t.Errorf("Got power %d left on the siphoned node, want %d", got, want)
+	}
+}
+
+func TestSiphonKeepsNodeStandingAndExpires(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_RealTime)
+	drained := fix.game.players[alice].Tree.GetTop().Find(4)
+	drained.Power = SiphonRate + 2
+
+	siphon := nodeAction(pb.ActionType_Siphon, alice, 4)
+	siphon.OwnNode = 4
+
+	fix.submit(t, bob, siphon)
+
+	for range SiphonDuration + 1 {
+		fix.game.tick()
+	}
+
+	// The first turn transfers the full rate, and the next one all but the last power of the node.
+	if got := drained.GetPower(); got != 1 {
+		t.Errorf("Got power %d left on the siphoned node, want 1", got)
+	}
+
+	recipient := fix.game.players[bob].Tree.GetTop().Find(4)
+	if got, want := recipient.GetPower(), int32(DefaultPower+SiphonRate+1); got != want {
+		t.Errorf("Got power %d siphoned into the node of bob, want %d", got, want)
+	}
+
+	if fix.game.players[alice].hasEffect(4, pb.StatusEffectType_PowerSiphon) {
+		t.Errorf("Got the siphon attached after %d turns, want it expired", SiphonDuration)
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	SniperCost     = 35
+	SniperCooldown = 6
+	SniperDamage   = 40
+)
+
+// applySniper strikes the node the target path of the action leads to in the tree of the opponent. The path is
+// followed by label regardless of visibility and without revealing the nodes along it, so deep nodes can be hit
+// blindly. A path that leads nowhere or to a fallen node misses, which still spends the shot.
+func applySniper(g *Game, _ string, action *pb.Action) outcome {
+	owner := action.GetTargetPlayer()
+	changes := []*pb.NodeChange{}
+
+	node := g.players[owner].Tree.GetTop().Descend(action.GetTargetPath())
+	if node != nil && !node.GetDestroyed() {
+		changes = append(changes, damageNode(owner, node, SniperDamage))
+	}
+
+	return outcome{changes: changes, private: nil, targeted: nil}
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestSniperFollowsPathBlindly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path []string
+		hit  int32
+	}{
+		{name: "deep obscured node", path: []string{"a", "b"}, hit: 2},
+		{name: "top for an empty path", path: nil, hit: 0},
+		{name: "missing label", path: []string{"a", "x"}, hit: -1},
+		{name: "past a leaf", path: []string{"c", "b"}, hit: -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+			fix.game.players[alice].unlocked[pb.ActionType_Sniper] = true
+			top := obscure(fix.game.players[bob].Tree.GetTop())
+
+			shot := nodeAction(pb.ActionType_Sniper, bob, 0)
+			shot.TargetPath = test.path
+
+			fix.submit(t, alice, shot)
+
+			changes := fix.resolved(alice)[0].GetChanges()
+
+			switch {
+			case test.hit < 0 && len(changes) != 0:
+				t.Errorf("Got changes %v from a shot that leads nowhere, want a miss", changes)
+			case test.hit >= 0 && (len(changes) != 1 || changes[0].GetNode() != test.hit):
+				t.Errorf("Got changes %v, want a hit on node %d", changes, test.hit)
+			}
+
+			if got := top.Find(1).GetVisibility(); got != pb.Visibility_Obscured {
+				t.Errorf("Got node 1 along the path %s after the shot, want it still obscured", got)
+			}
+
+			cooldowns := fix.game.players[alice].Cooldowns
+			if got := cooldowns[pb.ActionType_Sniper.String()]; got != SniperCooldown {
+				t.Errorf("Got cooldown %d after the shot, want %d", got, SniperCooldown)
+			}
+		})
+	}
+}
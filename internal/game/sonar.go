@@ -0,0 +1,66 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	SonarCost     = 15
+	SonarCooldown = 3
+	// SonarReach is the number of unrevealed nodes a single sonar ping reveals.
+	SonarReach = 6
+)
+
+// applySonar pings the tree of the opponent breadth-first from its top. Nodes already revealed to the caster are
+// transparent to the ping, as are nodes whose shield is down to half of its maximum, so the ping passes on to
+// their children. The first unrevealed nodes it reaches form the frontline, which is revealed up to the reach of
+// the sonar. Revealed nodes become Probed, except for Inferred nodes, which keep their label and become Visible.
+// Every node the ping reaches is probed, which breaks its mask.
+func applySonar(g *Game, _ string, action *pb.Action) outcome {
+	owner := g.players[action.GetTargetPlayer()]
+	revealed := []*pb.FsTreeNode{}
+
+	queue := []*pb.FsTreeNode{owner.Tree.GetTop()}
+
+	for len(queue) > 0 && len(revealed) < SonarReach {
+		node := queue[0]
+		queue = queue[1:]
+
+		owner.unmask(node.GetId())
+
+		transparent := node.GetVisibility() >= pb.Visibility_Probed ||
+			node.GetShield()*2 <= node.GetMaxShield()
+
+		if node.GetVisibility() < pb.Visibility_Probed {
+			node.Visibility = probedVisibility(node.GetVisibility())
+			revealed = append(revealed, node)
+		}
+
+		if transparent {
+			queue = append(queue, node.GetChildren()...)
+		}
+	}
+
+	nodes := make([]*pb.FsTreeNode, 0, len(revealed))
+	disguises := owner.disguises()
+
+	for _, node := range revealed {
+		nodes = append(nodes, projectShallow(node, disguises))
+	}
+
+	return outcome{
+		changes: nil,
+		private: []pb.GameEventPayload{&pb.GameEvent_ScanResult{
+			ScanResult: &pb.ScanResult{TargetPlayer: owner.ID, Nodes: nodes},
+		}},
+		targeted: nil,
+	}
+}
+
+func probedVisibility(visibility pb.Visibility) pb.Visibility {
+	if visibility == pb.Visibility_Inferred {
+		return pb.Visibility_Visible
+	}
+
+	return pb.Visibility_Probed
+}
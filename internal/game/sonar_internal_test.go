@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestSonarRevealsFrontline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prepare func(top *pb.FsTreeNode)
+		want    map[int32]pb.Visibility
+		name    string
+	}{
+		{
+			name:    "shielded nodes stop the ping",
+			prepare: func(*pb.FsTreeNode) {},
+			want: map[int32]pb.Visibility{
+				1: pb.Visibility_Probed,
+				2: pb.Visibility_Obscured,
+				3: pb.Visibility_Probed,
+				4: pb.Visibility_Probed,
+			},
+		},
+		{
+			name:    "nodes at half shield let the ping through",
+			prepare: func(top *pb.FsTreeNode) { top.Find(1).Shield = DefaultShield / 2 },
+			want: map[int32]pb.Visibility{
+				1: pb.Visibility_Probed,
+				2: pb.Visibility_Probed,
+				3: pb.Visibility_Probed,
+				4: pb.Visibility_Probed,
+			},
+		},
+		{
+			name:    "revealed nodes let the ping through",
+			prepare: func(top *pb.FsTreeNode) { top.Find(1).Visibility = pb.Visibility_Probed },
+			want: map[int32]pb.Visibility{
+				1: pb.Visibility_Probed,
+				2: pb.Visibility_Probed,
+				3: pb.Visibility_Probed,
+				4: pb.Visibility_Probed,
+			},
+		},
+		{
+			name:    "inferred nodes become visible",
+			prepare: func(top *pb.FsTreeNode) { top.Find(3).Visibility = pb.Visibility_Inferred },
+			want: map[int32]pb.Visibility{
+				1: pb.Visibility_Probed,
+				2: pb.Visibility_Obscured,
+				3: pb.Visibility_Visible,
+				4: pb.Visibility_Probed,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fix := newFixture(t, pb.GameMode_Turns)
+			top := obscure(fix.game.players[bob].Tree.GetTop())
+			test.prepare(top)
+
+			fix.submit(t, alice, sonar(bob))
+
+			for id, want := range test.want {
+				if got := top.Find(id).GetVisibility(); got != want {
+					t.Errorf("Got node %d %s, want %s", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSonarReach(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	tree := fix.game.players[bob].Tree
+
+	for idx := range SonarReach {
+		extra := NewFsTreeNode(fmt.Sprint("e", idx), pb.Visibility_Obscured)
+		tree.Top.Children = append(tree.Top.Children, extra)
+	}
+
+	prepareTree(tree)
+	obscure(tree.GetTop())
+
+	fix.submit(t, alice, sonar(bob))
+
+	var probed int
+
+	tree.GetTop().Walk(func(node *pb.FsTreeNode) {
+		if node != tree.GetTop() && node.GetVisibility() == pb.Visibility_Probed {
+			probed++
+		}
+	})
+
+	if probed != SonarReach {
+		t.Errorf("Got %d nodes revealed, want the reach of %d", probed, SonarReach)
+	}
+}
+
+func TestSonarBreaksMasks(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	owner := fix.game.players[bob]
+	owner.applyEffect(&effect{
+		disguise:  &pb.Disguise{Label: "decoy", Power: nil, Shield: nil, Sentinel: nil},
+		source:    bob,
+		kind:      pb.StatusEffectType_Masked,
+		node:      3,
+		recipient: 0,
+		dormant:   0,
+		remaining: 0,
+		stacks:    0,
+		detected:  false,
+	})
+
+	fix.submit(t, alice, sonar(bob))
+
+	if owner.hasEffect(3, pb.StatusEffectType_Masked) {
+		t.Error("Got node 3 still masked after the ping, want the mask broken")
+	}
+}
+
+// obscure hides every node below the top from opponents and returns the top.
+func obscure(top *pb.FsTreeNode) *pb.FsTreeNode {
+	top.Walk(func(node *pb.FsTreeNode) {
+		if node != top {
+			node.Visibility = pb.Visibility_Obscured
+		}
+	})
+
+	return top
+}
+
+// sonar builds a sonar ping aimed at the tree of the targeted player.
+func sonar(targetPlayer string) *pb.Action {
+	return nodeAction(pb.ActionType_Sonar, targetPlayer, 0)
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestCanActFollowsTurnOrder(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+
+	turns := []struct{ acting, waiting string }{{alice, bob}, {bob, alice}, {alice, bob}}
+
+	for _, turn := range turns {
+		if !fix.game.CanAct(turn.acting) || fix.game.CanAct(turn.waiting) {
+			t.Fatalf(
+				"Got %s unable or %s able to act, want only %s acting",
+				turn.acting,
+				turn.waiting,
+				turn.acting,
+			)
+		}
+
+		fix.submit(t, turn.acting, nodeAction(pb.ActionType_EndTurn, "", 0))
+	}
+}
+
+func TestCanActInRealTime(t *testing.T) {
+	t.Parallel()
+
+	// Carol never joined the fixture game.
+	fix := newFixture(t, pb.GameMode_RealTime)
+	fix.game.players[bob].Eliminated = true
+
+	tests := []struct {
+		player string
+		want   bool
+	}{
+		{player: alice, want: true},
+		{player: bob, want: false},
+		{player: carol, want: false},
+	}
+
+	for _, test := range tests {
+		if got := fix.game.CanAct(test.player); got != test.want {
+			t.Errorf("Got %s able to act %t, want %t", test.player, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	ToggleUpkeepCost = 5
+)
+
+// applyToggleUpkeep turns the targeted node of the caster into an upkeep node, or back into a regular one.
+func applyToggleUpkeep(g *Game, playerID string, action *pb.Action) outcome {
+	node := g.players[playerID].Tree.GetTop().Find(action.GetTargetNode())
+	if node == nil {
+		return outcome{changes: nil, private: nil, targeted: nil}
+	}
+
+	node.Upkeep = !node.GetUpkeep()
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+// shareUpkeep hands the recharge of the upkeep node over to its neighbors: its parent, its siblings and its children.
+// Neighbors that have fallen or keep upkeep themselves are passed over. The recharge is split evenly, with the
+// remainder going to the first neighbors in that order, and is lost when there is no neighbor to take it.
+func shareUpkeep(node, parent *pb.FsTreeNode, recharges map[*pb.FsTreeNode]int32) {
+	neighbors := []*pb.FsTreeNode{}
+
+	if parent != nil {
+		neighbors = append(neighbors, parent)
+		neighbors = append(neighbors, parent.GetChildren()...)
+	}
+
+	neighbors = append(neighbors, node.GetChildren()...)
+	neighbors = slices.DeleteFunc(neighbors, func(neighbor *pb.FsTreeNode) bool {
+		return neighbor == node || neighbor.GetDestroyed() || neighbor.GetUpkeep()
+	})
+
+	recharge := recharges[node]
+	recharges[node] = 0
+
+	if len(neighbors) == 0 {
+		return
+	}
+
+	//nolint:gosec // Neighbors are bounded by the width of the tree.
+	share, remainder := recharge/int32(len(neighbors)), recharge%int32(len(neighbors))
+
+	for idx, neighbor := range neighbors {
+		recharges[neighbor] += share
+
+		//nolint:gosec // Neighbors are bounded by the width of the tree.
+		if int32(idx) < remainder {
+			recharges[neighbor]++
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestShareUpkeep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		prepare  func(top *pb.FsTreeNode)
+		want     map[int32]int32
+		name     string
+		recharge int32
+	}{
+		{
+			name:     "remainder to the first neighbors",
+			prepare:  func(*pb.FsTreeNode) {},
+			recharge: 9,
+			want:     map[int32]int32{0: 3, 1: 0, 2: 2, 3: 2, 4: 2},
+		},
+		{
+			name: "fallen and upkeep neighbors passed over",
+			prepare: func(top *pb.FsTreeNode) {
+				top.Find(0).Upkeep, top.Find(3).Destroyed = true, true
+			},
+			recharge: 9,
+			want:     map[int32]int32{0: 0, 1: 0, 2: 4, 3: 0, 4: 5},
+		},
+		{
+			name: "lost without neighbors",
+			prepare: func(top *pb.FsTreeNode) {
+				for _, id := range []int32{0, 2, 3, 4} {
+					top.Find(id).Destroyed = true
+				}
+			},
+			recharge: 9,
+			want:     map[int32]int32{0: 0, 1: 0, 2: 0, 3: 0, 4: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			top := fixtureTree().GetTop()
+			prepareTree(&pb.FsTree{Top: top})
+			test.prepare(top)
+
+			// Node a shares with its parent, its siblings c and d and its child b, in that order.
+			node := top.Find(1)
+			recharges := map[*pb.FsTreeNode]int32{node: test.recharge}
+
+			shareUpkeep(node, top, recharges)
+
+			for id, want := range test.want {
+				if got := recharges[top.Find(id)]; got != want {
+					t.Errorf("Got recharge %d for node %d, want %d", got, id, want)
+				}
+			}
+		})
+	}
+}
+
+func TestToggleUpkeepSharesRechargeOnTurn(t *testing.T) {
+	t.Parallel()
+
+	fix := newFixture(t, pb.GameMode_Turns)
+	top := fix.game.players[bob].Tree.GetTop()
+
+	top.Walk(func(node *pb.FsTreeNode) {
+		node.Shield = 0
+	})
+
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+	fix.submit(t, bob, nodeAction(pb.ActionType_ToggleUpkeep, bob, 3))
+	fix.submit(t, bob, nodeAction(pb.ActionType_EndTurn, "", 0))
+	fix.submit(t, alice, nodeAction(pb.ActionType_EndTurn, "", 0))
+
+	// Node c recharged on the first turn of bob, and hands its recharge to top, a and d on the second.
+	want := map[int32]int32{0: 5, 1: 5, 2: 4, 3: 2, 4: 4}
+
+	for id, shield := range want {
+		if got := top.Find(id).GetShield(); got != shield {
+			t.Errorf("Got node %d at shield %d, want %d", id, got, shield)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	VentriloquistCost     = 20
+	VentriloquistCooldown = 4
+)
+
+// frame is a pending Ventriloquist trick on a player, whose next action is shown to others as taken by the framed
+// player.
+type frame struct {
+	caster string
+	framed string
+}
+
+// targetFramedPlayer accepts standing opponents framed as any other player of the game.
+func targetFramedPlayer(g *Game, playerID string, action *pb.Action) error {
+	err := targetOpponent(g, playerID, action)
+	if err != nil {
+		return err
+	}
+
+	_, ok := g.players[action.GetFramedPlayer()]
+	if !ok || action.GetFramedPlayer() == action.GetTargetPlayer() {
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// applyVentriloquist frames the next action of the targeted player, replacing any earlier trick on them.
+func applyVentriloquist(g *Game, playerID string, action *pb.Action) outcome {
+	g.frames[action.GetTargetPlayer()] = frame{caster: playerID, framed: action.GetFramedPlayer()}
+
+	return outcome{changes: nil, private: nil, targeted: nil}
+}
+
+// filter shows the resolved action of the actor as taken by the framed player to everyone but the actor and the
+// caster of the trick.
+func (f frame) filter(actorID string) eventFilter {
+	return func(viewerID string, event *pb.GameEvent) *pb.GameEvent {
+		if viewerID == actorID || viewerID == f.caster || event.GetActionResolved() == nil {
+			return event
+		}
+
+		framed := proto.CloneOf(event)
+		framed.GetActionResolved().Player = f.framed
+
+		return framed
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/passeriform/internal/pb"
+)
+
+func TestVentriloquistFramesNextAction(t *testing.T) {
+	t.Parallel()
+
+	fix := newTrioFixture(t, pb.GameMode_RealTime)
+
+	trick := nodeAction(pb.ActionType_Ventriloquist, bob, 0)
+	trick.FramedPlayer = alice
+
+	fix.submit(t, alice, trick)
+	fix.game.tick()
+
+	for range 2 {
+		fix.submit(t, bob, nodeAction(pb.ActionType_Strike, carol, 4))
+		fix.game.tick()
+	}
+
+	// Only the first strike is framed, and only in the eyes of the struck player.
+	want := map[string][]string{
+		alice: {bob, bob},
+		bob:   {bob, bob},
+		carol: {alice, bob},
+	}
+
+	for viewer, actors := range want {
+		strikes := []string{}
+
+		for _, resolved := range fix.resolved(viewer) {
+			if resolved.GetAction().GetType() == pb.ActionType_Strike {
+				strikes = append(strikes, resolved.GetPlayer())
+			}
+		}
+
+		if !slices.Equal(strikes, actors) {
+			t.Errorf("Got strikes shown to %s as taken by %v, want %v", viewer, strikes, actors)
+		}
+	}
+}
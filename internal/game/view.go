@@ -0,0 +1,90 @@
+package game
+
+import (
+	"github.com/passeriform/internal/pb"
+)
+
+// ProjectTree returns a copy of the tree as seen by an opponent. Node data is redacted according to its visibility:
+// Obscured nodes only reveal their position, Inferred nodes their label, Probed nodes their ratings, Visible nodes
+// both and VisibleSentinel nodes additionally reveal whether they are sentinels. Upkeep roles are revealed along with
+// the ratings. Masked nodes show their disguise in place of whatever data their visibility reveals.
+func ProjectTree(tree *pb.FsTree, disguises map[int32]*pb.Disguise) *pb.FsTree {
+	return &pb.FsTree{Top: projectNode(tree.GetTop(), disguises)}
+}
+
+func projectNode(node *pb.FsTreeNode, disguises map[int32]*pb.Disguise) *pb.FsTreeNode {
+	visibility := node.GetVisibility()
+
+	children := make([]*pb.FsTreeNode, 0, len(node.GetChildren()))
+
+	for _, child := range node.GetChildren() {
+		children = append(children, projectNode(child, disguises))
+	}
+
+	projected := &pb.FsTreeNode{
+		Label:         "",
+		Children:      children,
+		ChildrenCount: node.GetChildrenCount(),
+		NestedCount:   node.GetNestedCount(),
+		Sentinel:      false,
+		Power:         0,
+		Shield:        0,
+		RechargeRate:  0,
+		Visibility:    visibility,
+		Id:            node.GetId(),
+		Destroyed:     node.GetDestroyed(),
+		MaxShield:     0,
+		Effects:       []*pb.StatusEffect{},
+		Upkeep:        false,
+	}
+
+	if visibility == pb.Visibility_Inferred || visibility >= pb.Visibility_Visible {
+		projected.Label = node.GetLabel()
+	}
+
+	if visibility >= pb.Visibility_Probed {
+		projected.Power, projected.Shield = node.GetPower(), node.GetShield()
+		projected.RechargeRate, projected.MaxShield = node.GetRechargeRate(), node.GetMaxShield()
+		projected.Upkeep = node.GetUpkeep()
+	}
+
+	if visibility == pb.Visibility_VisibleSentinel {
+		projected.Sentinel = node.GetSentinel()
+	}
+
+	if disguise, ok := disguises[node.GetId()]; ok && !node.GetDestroyed() {
+		disguiseNode(projected, disguise)
+	}
+
+	return projected
+}
+
+// disguiseNode replaces the revealed data of a projected node with the data set in the disguise.
+func disguiseNode(projected *pb.FsTreeNode, disguise *pb.Disguise) {
+	visibility := projected.GetVisibility()
+
+	if projected.GetLabel() != "" && disguise.GetLabel() != "" {
+		projected.Label = disguise.GetLabel()
+	}
+
+	if visibility >= pb.Visibility_Probed && disguise.Power != nil {
+		projected.Power = disguise.GetPower()
+	}
+
+	if visibility >= pb.Visibility_Probed && disguise.Shield != nil {
+		projected.Shield = disguise.GetShield()
+		projected.MaxShield = max(projected.GetMaxShield(), disguise.GetShield())
+	}
+
+	if visibility == pb.Visibility_VisibleSentinel && disguise.Sentinel != nil {
+		projected.Sentinel = disguise.GetSentinel()
+	}
+}
+
+// projectShallow projects a single node as seen by an opponent, without its children.
+func projectShallow(node *pb.FsTreeNode, disguises map[int32]*pb.Disguise) *pb.FsTreeNode {
+	projected := projectNode(node, disguises)
+	projected.Children = []*pb.FsTreeNode{}
+
+	return projected
+}
@@ -1,5 +1,9 @@
 package pb
 
+import (
+	"slices"
+)
+
 const TabIndentSize = 4
 
 func (node *FsTreeNode) getChildrenCount() (int, int) {
@@ -24,3 +28,139 @@ func (node *FsTreeNode) WithChildren(children []*FsTreeNode) *FsTreeNode {
 
 	return node
 }
+
+// Walk visits the node and all of its descendants in pre-order.
+func (node *FsTreeNode) Walk(visit func(*FsTreeNode)) {
+	visit(node)
+
+	for _, child := range node.GetChildren() {
+		child.Walk(visit)
+	}
+}
+
+// Find returns the node with the given id from the subtree rooted at this node, or nil if it does not exist.
+func (node *FsTreeNode) Find(id int32) *FsTreeNode {
+	if node.GetId() == id {
+		return node
+	}
+
+	for _, child := range node.GetChildren() {
+		if found := child.Find(id); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// Path returns the nodes from this node down to the node with the given id, both included, or nil if no node in the
+// subtree rooted at this node has the id.
+func (node *FsTreeNode) Path(id int32) []*FsTreeNode {
+	if node.GetId() == id {
+		return []*FsTreeNode{node}
+	}
+
+	for _, child := range node.GetChildren() {
+		if path := child.Path(id); path != nil {
+			return append([]*FsTreeNode{node}, path...)
+		}
+	}
+
+	return nil
+}
+
+// Detach removes the subtree rooted at the node with the given id from the subtree rooted at this node and returns
+// it, or nil if no descendant has the id. The children counts of every ancestor of the removed subtree are updated.
+func (node *FsTreeNode) Detach(id int32) *FsTreeNode {
+	path := node.Path(id)
+	if len(path) < 2 { //nolint:mnd // A parent and its child.
+		return nil
+	}
+
+	detached, parent := path[len(path)-1], path[len(path)-2]
+
+	parent.Children = slices.DeleteFunc(parent.GetChildren(), func(child *FsTreeNode) bool {
+		return child == detached
+	})
+	parent.ChildrenCount--
+
+	for _, ancestor := range path[:len(path)-1] {
+		ancestor.NestedCount -= detached.GetNestedCount() + 1
+	}
+
+	return detached
+}
+
+// Descend returns the descendant reached by following the labels of the path down from this node, or nil if no child
+// along the way carries the next label. An empty path leads to this node.
+func (node *FsTreeNode) Descend(path []string) *FsTreeNode {
+	if len(path) == 0 {
+		return node
+	}
+
+	for _, child := range node.GetChildren() {
+		if child.GetLabel() == path[0] {
+			return child.Descend(path[1:])
+		}
+	}
+
+	return nil
+}
+
+// GameEventPayload is implemented by every payload that can be carried by a GameEvent.
+type GameEventPayload = isGameEvent_Payload
+
+// GameLogEntryPayload is implemented by every entry that can be recorded in a GameLogEntry.
+type GameLogEntryPayload = isGameLogEntry_Entry
+
+// ObservationTrigger is implemented by everything that can prompt an Observation for a bot.
+type ObservationTrigger = isObservation_Trigger
+
+// Neighborhood returns the nodes of the subtree rooted at this node that lie within radius edges of the node with the
+// given id, starting with that node and ordered by distance. Distance is counted along the edges of the tree, so the
+// neighborhood spreads to parents, siblings and descendants alike. It is empty if no node has the id.
+func (node *FsTreeNode) Neighborhood(id int32, radius int) []*FsTreeNode {
+	parents := map[*FsTreeNode]*FsTreeNode{}
+
+	var center *FsTreeNode
+
+	node.Walk(func(visited *FsTreeNode) {
+		if visited.GetId() == id && center == nil {
+			center = visited
+		}
+
+		for _, child := range visited.GetChildren() {
+			parents[child] = visited
+		}
+	})
+
+	if center == nil {
+		return []*FsTreeNode{}
+	}
+
+	distances := map[*FsTreeNode]int{center: 0}
+	found := []*FsTreeNode{center}
+
+	for idx := 0; idx < len(found); idx++ {
+		current := found[idx]
+		if distances[current] == radius {
+			continue
+		}
+
+		adjacent := current.GetChildren()
+		if parent, ok := parents[current]; ok {
+			adjacent = append([]*FsTreeNode{parent}, adjacent...)
+		}
+
+		for _, next := range adjacent {
+			if _, seen := distances[next]; seen {
+				continue
+			}
+
+			distances[next] = distances[current] + 1
+			found = append(found, next)
+		}
+	}
+
+	return found
+}
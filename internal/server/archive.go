@@ -0,0 +1,52 @@
+package server
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/passeriform/internal/game"
+)
+
+// ArchiveCapacity is the number of games the archive holds before it evicts the oldest finished ones.
+const ArchiveCapacity = 256
+
+//nolint:gochecknoglobals // Holding a global map for games against wrapping struct.
+var (
+	gameArchive      = map[string]*game.Game{}
+	gameArchiveOrder = []string{}
+	gameArchiveMu    sync.RWMutex
+)
+
+// ArchiveGame keeps the game available for replays once it is over. When the archive grows past its capacity, the
+// oldest finished games are evicted, while games still being played are kept.
+// TODO: Persist archived games instead of evicting them.
+func ArchiveGame(g *game.Game) {
+	gameArchiveMu.Lock()
+	defer gameArchiveMu.Unlock()
+
+	if _, ok := gameArchive[g.ID()]; !ok {
+		gameArchiveOrder = append(gameArchiveOrder, g.ID())
+	}
+
+	gameArchive[g.ID()] = g
+
+	excess := len(gameArchiveOrder) - ArchiveCapacity
+
+	gameArchiveOrder = slices.DeleteFunc(gameArchiveOrder, func(id string) bool {
+		if excess <= 0 || !gameArchive[id].Over() {
+			return false
+		}
+
+		delete(gameArchive, id)
+		excess--
+
+		return true
+	})
+}
+
+func GetArchivedGame(gameID string) *game.Game {
+	gameArchiveMu.RLock()
+	defer gameArchiveMu.RUnlock()
+
+	return gameArchive[gameID]
+}
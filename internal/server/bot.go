@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/necmettindev/randomstring"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	BotIDPrefix = "BOT-"
+
+	// maxBotActions bounds the actions a bot takes on a single turn, in case the game keeps accepting them.
+	maxBotActions = 32
+
+	botTreeDepth           = 3
+	botTreeWidth           = 4
+	botTreeVisibilityDepth = 2
+)
+
+// Bot is an in-process player. It joins a room through its own connection, publishes a generated tree once the game
+// starts and plays through the same game API as human players.
+type Bot struct {
+	conn     *Connection
+	room     *Room
+	strategy game.Strategy
+	adaptive *game.Adaptive
+	stop     chan struct{}
+}
+
+// FillWithBots adds bots of the difficulty to every empty slot of the room and returns their connection ids. Bots
+// are always ready, so the game starts as soon as the remaining players are. Adaptive bots adapt to the plays of
+// their opponents at the rate, or at the default rate when it is 0.
+func (room *Room) FillWithBots(difficulty pb.BotDifficulty, rate float64) []string {
+	ids := []string{}
+
+	for room.size() < room.RequiredPlayers {
+		bot := newBot(room, difficulty, rate)
+
+		go bot.run()
+
+		room.mu.Lock()
+		room.bots[bot.conn.ID] = bot
+		room.mu.Unlock()
+
+		room.AddConnection(bot.conn)
+
+		ids = append(ids, bot.conn.ID)
+	}
+
+	// The remaining players may already be ready.
+	room.machine.Event(context.Background(), RoomEventAttemptGameStart.String())
+
+	return ids
+}
+
+// IsBot reports whether the connection belongs to a bot.
+func (room *Room) IsBot(connID string) bool {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	_, ok := room.bots[connID]
+
+	return ok
+}
+
+func newBot(room *Room, difficulty pb.BotDifficulty, rate float64) *Bot {
+	suffix, err := randomstring.GenerateString(randomstring.GenerationOptions{
+		Length:           ConnectionIDLength,
+		DisableNumeric:   true,
+		DisableLowercase: true,
+	})
+	if err != nil {
+		log.Panicf("Error occurred while creating bot id: %v", err)
+	}
+
+	id := BotIDPrefix + suffix
+	strategy := game.NewStrategy(difficulty, game.NewSeed())
+
+	faction := pb.Faction_Rogue
+
+	var adaptive *game.Adaptive
+
+	if difficulty == pb.BotDifficulty_Adaptive {
+		adaptive = game.NewAdaptive(id, rate, game.NewSeed())
+		strategy, faction = adaptive.Act, game.AdaptiveFaction
+	}
+
+	return &Bot{
+		conn:     newConnection(id, room, faction, true),
+		room:     room,
+		strategy: strategy,
+		adaptive: adaptive,
+		stop:     make(chan struct{}),
+	}
+}
+
+// removeBots stops every bot of the room and removes their connections.
+func (room *Room) removeBots() {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	for id, bot := range room.bots {
+		delete(room.clients, id)
+		delete(room.bots, id)
+		close(bot.stop)
+		bot.conn.close()
+	}
+}
+
+func (bot *Bot) run() {
+	var current *game.Game
+
+	for {
+		select {
+		case msg := <-bot.conn.MsgChan:
+			if msg.GetType() == pb.RoomState_InGame {
+				current = bot.room.Game
+				bot.publishTree(current)
+			}
+
+		case event := <-bot.conn.GameChan:
+			if bot.adaptive != nil {
+				bot.adaptive.Observe(event)
+			}
+
+			turn := event.GetTurnStarted()
+
+			if current != nil && turn != nil && turn.GetPlayer() == bot.conn.ID {
+				bot.act(current)
+			}
+
+		case <-bot.stop:
+			return
+		}
+	}
+}
+
+// publishTree publishes the opponent tree of the mission of the room, or a freshly generated tree outside of missions.
+func (bot *Bot) publishTree(g *game.Game) {
+	tree := bot.room.MissionTree(bot.conn.ID)
+	if tree == nil {
+		generated := game.NewBotTree(game.NewSeed(), game.TreeGenOptions{
+			Ignore:          nil,
+			VisibilityDepth: botTreeVisibilityDepth,
+			Depth:           botTreeDepth,
+			Width:           botTreeWidth,
+		})
+		tree = &generated
+	}
+
+	err := g.AddPlayerState(bot.conn.ID, tree)
+	if err != nil {
+		log.Printf("Bot %s could not publish its tree: %v", bot.conn.ID, err)
+	}
+}
+
+// act spends the budget of the bot on the counter of adaptive bots and then on the actions picked by its strategy. In
+// turn mode the bot ends its turn once it is done, so a failing action never stalls the game.
+func (bot *Bot) act(g *game.Game) {
+	bot.counter(g)
+
+	for range maxBotActions {
+		board, err := g.Board(bot.conn.ID)
+		if err != nil {
+			return
+		}
+
+		action := bot.strategy(board)
+		if action.GetType() == pb.ActionType_EndTurn {
+			break
+		}
+
+		err = g.Submit(bot.conn.ID, action)
+		if err != nil {
+			log.Printf("Bot %s could not act: %v", bot.conn.ID, err)
+			break
+		}
+	}
+
+	if g.Over() {
+		return
+	}
+
+	board, err := g.Board(bot.conn.ID)
+	if err != nil || board.GetMode() != pb.GameMode_Turns {
+		return
+	}
+
+	if board.GetCurrentPlayer() != bot.conn.ID {
+		return
+	}
+
+	endTurn := &pb.Action{
+		Type:         pb.ActionType_EndTurn,
+		TargetPlayer: "",
+		TargetNode:   0,
+		TargetNodes:  nil,
+		Disguise:     nil,
+		OwnNode:      0,
+		FramedPlayer: "",
+		TargetPath:   nil,
+	}
+
+	err = g.Submit(bot.conn.ID, endTurn)
+	if err != nil {
+		log.Printf("Bot %s could not end its turn: %v", bot.conn.ID, err)
+	}
+}
+
+// counter buys the upgrade an adaptive bot counters the plays of its opponents with, if any.
+func (bot *Bot) counter(g *game.Game) {
+	if bot.adaptive == nil {
+		return
+	}
+
+	board, err := g.Board(bot.conn.ID)
+	if err != nil {
+		return
+	}
+
+	purchase := bot.adaptive.Counter(board)
+	if purchase == nil {
+		return
+	}
+
+	err = g.Purchase(bot.conn.ID, purchase)
+	if err != nil {
+		log.Printf("Bot %s could not purchase a counter: %v", bot.conn.ID, err)
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+//nolint:gochecknoglobals // Campaign is only loaded once at startup.
+var campaign *pb.Campaign
+
+// LoadCampaign loads the missions served to clients from the data file at the path, or the campaign shipped with
+// the game when the path is empty.
+func LoadCampaign(path string) error {
+	if path == "" {
+		loaded, err := game.DefaultCampaign()
+		if err != nil {
+			return fmt.Errorf("could not load default campaign: %w", err)
+		}
+
+		campaign = loaded
+
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open campaign: %w", err)
+	}
+	defer file.Close()
+
+	loaded, err := game.LoadCampaign(file)
+	if err != nil {
+		return fmt.Errorf("could not load campaign %s: %w", path, err)
+	}
+
+	campaign = loaded
+
+	return nil
+}
+
+// Campaign returns the loaded campaign.
+func Campaign() *pb.Campaign {
+	return campaign
+}
+
+// GetMission returns the mission of the loaded campaign with the id, or nil.
+func GetMission(missionID string) *pb.Mission {
+	for _, mission := range campaign.GetMissions() {
+		if mission.GetId() == missionID {
+			return mission
+		}
+	}
+
+	return nil
+}
+
+// MissionTree returns the tree the connection plays with in the mission of its room, or nil outside of missions.
+// Bots defend the opponent tree while every other player defends the player tree.
+func (room *Room) MissionTree(connID string) *pb.FsTree {
+	if room.Mission == nil {
+		return nil
+	}
+
+	player, opponent := game.MissionTrees(room.Mission)
+	if room.IsBot(connID) {
+		return opponent
+	}
+
+	return player
+}
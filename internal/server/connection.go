@@ -1,18 +1,43 @@
 package server
 
 import (
+	"log"
+	"sync"
+
 	"github.com/passeriform/internal/pb"
 )
 
+const (
+	GameEventBufferSize = 64
+	// OutboxCapacity is the number of game events queued for a connection before it is cut off from game events.
+	OutboxCapacity = 1024
+)
+
 //nolint:gochecknoglobals // Holding a global map for connections against wrapping struct.
 var connectionMap = map[string]*Connection{}
 
-type Connection struct {
-	Room    *Room
-	MsgChan chan *pb.MessageStreamResponse
-	ID      string
-	Ready   bool
-}
+type (
+	Connection struct {
+		Room     *Room
+		MsgChan  chan *pb.MessageStreamResponse
+		GameChan chan *pb.GameEvent
+		outbox   *outbox
+		ID       string
+		Faction  pb.Faction
+		Ready    bool
+	}
+
+	// outbox queues the game events of a connection until they are forwarded to its GameChan, so that the game never
+	// blocks on a reader that falls behind. A reader that falls behind by more than the capacity of the outbox, or
+	// never reads at all, is cut off from game events instead of growing the queue without limit.
+	outbox struct {
+		wake   chan struct{}
+		done   chan struct{}
+		events []*pb.GameEvent
+		closed sync.Once
+		mu     sync.Mutex
+	}
+)
 
 func CreateConnection(connID string) {
 	_, ok := connectionMap[connID]
@@ -21,14 +46,29 @@ func CreateConnection(connID string) {
 		return
 	}
 
+	connectionMap[connID] = newConnection(connID, nil, pb.Faction_Rogue, false)
+}
+
+func newConnection(connID string, room *Room, faction pb.Faction, ready bool) *Connection {
 	conn := &Connection{
-		Room:    nil,
-		MsgChan: make(chan *pb.MessageStreamResponse),
+		Room:     room,
+		MsgChan:  make(chan *pb.MessageStreamResponse),
+		GameChan: make(chan *pb.GameEvent, GameEventBufferSize),
+		outbox: &outbox{
+			wake:   make(chan struct{}, 1),
+			done:   make(chan struct{}),
+			events: []*pb.GameEvent{},
+			closed: sync.Once{},
+			mu:     sync.Mutex{},
+		},
 		ID:      connID,
-		Ready:   false,
+		Faction: faction,
+		Ready:   ready,
 	}
 
-	connectionMap[connID] = conn
+	go conn.forwardGameEvents()
+
+	return conn
 }
 
 func GetConnection(connID string) *Connection {
@@ -37,9 +77,83 @@ func GetConnection(connID string) *Connection {
 }
 
 func (conn *Connection) Remove() {
-	if connectionMap[conn.ID].Room != nil {
-		connectionMap[conn.ID].Room.RemoveConnection(conn.ID)
+	if registered, ok := connectionMap[conn.ID]; ok && registered.Room != nil {
+		registered.Room.RemoveConnection(conn.ID)
 	}
 
+	conn.close()
+
 	delete(connectionMap, conn.ID)
 }
+
+// SendGameEvent queues the event for the GameChan of the connection without blocking. Connections whose outbox is
+// full are closed, dropping every queued event.
+func (conn *Connection) SendGameEvent(event *pb.GameEvent) {
+	conn.outbox.mu.Lock()
+
+	if len(conn.outbox.events) >= OutboxCapacity {
+		conn.outbox.events = nil
+		conn.outbox.mu.Unlock()
+
+		log.Printf("Client %s fell behind on game events and was cut off", conn.ID)
+		conn.close()
+
+		return
+	}
+
+	select {
+	case <-conn.outbox.done:
+	default:
+		conn.outbox.events = append(conn.outbox.events, event)
+	}
+
+	conn.outbox.mu.Unlock()
+
+	select {
+	case conn.outbox.wake <- struct{}{}:
+	default:
+	}
+}
+
+// forwardGameEvents forwards the queued game events to the GameChan in order until the connection is closed.
+func (conn *Connection) forwardGameEvents() {
+	for {
+		select {
+		case <-conn.outbox.wake:
+		case <-conn.outbox.done:
+			return
+		}
+
+		for event := conn.outbox.next(); event != nil; event = conn.outbox.next() {
+			select {
+			case conn.GameChan <- event:
+			case <-conn.outbox.done:
+				return
+			}
+		}
+	}
+}
+
+// Closed is closed once the connection no longer receives game events, because it was removed or fell behind.
+func (conn *Connection) Closed() <-chan struct{} {
+	return conn.outbox.done
+}
+
+// close stops forwarding game events to the connection.
+func (conn *Connection) close() {
+	conn.outbox.closed.Do(func() { close(conn.outbox.done) })
+}
+
+func (box *outbox) next() *pb.GameEvent {
+	box.mu.Lock()
+	defer box.mu.Unlock()
+
+	if len(box.events) == 0 {
+		return nil
+	}
+
+	event := box.events[0]
+	box.events[0], box.events = nil, box.events[1:]
+
+	return event
+}
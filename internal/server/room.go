@@ -3,6 +3,9 @@ package server
 import (
 	"context"
 	"log"
+	"maps"
+	"slices"
+	"sync"
 
 	"github.com/looplab/fsm"
 	"github.com/necmettindev/randomstring"
@@ -12,6 +15,7 @@ import (
 
 const (
 	ConnectionIDLength = 5
+	GameIDLength       = 8
 )
 
 var (
@@ -27,17 +31,28 @@ var (
 )
 
 type (
+	// Room holds the connections playing together. Connections join and leave while the game notifies them from its
+	// own goroutine, so they are guarded by a lock and only handed out as snapshots.
 	Room struct {
-		Clients         map[string]*Connection
+		clients         map[string]*Connection
 		Game            *game.Game
+		Mission         *pb.Mission
+		bots            map[string]*Bot
 		machine         *RoomFSM
 		ID              string
 		RequiredPlayers int
+		Type            pb.RoomType
+		Mode            pb.GameMode
+		mu              sync.RWMutex
 	}
 )
 
 //nolint:funlen,gocognit,revive // Room initialization also requires setting callbacks for state machine.
-func NewRoom(roomType pb.RoomType, stateChangeCallback func(*Room, pb.RoomState)) (*Room, bool) {
+func NewRoom(
+	roomType pb.RoomType,
+	mode pb.GameMode,
+	stateChangeCallback func(*Room, pb.RoomState),
+) (*Room, bool) {
 	roomID, err := randomstring.GenerateString(randomstring.GenerationOptions{
 		Length:           ConnectionIDLength,
 		DisableNumeric:   true,
@@ -54,26 +69,31 @@ func NewRoom(roomType pb.RoomType, stateChangeCallback func(*Room, pb.RoomState)
 	}
 
 	room = &Room{
-		Clients:         map[string]*Connection{},
+		clients:         map[string]*Connection{},
 		Game:            nil,
+		Mission:         nil,
+		bots:            map[string]*Bot{},
 		machine:         nil,
 		ID:              roomID,
 		RequiredPlayers: roomTypeRequiredPlayers[roomType],
+		Type:            roomType,
+		Mode:            mode,
+		mu:              sync.RWMutex{},
 	}
 
 	machine := NewRoomFSM(fsm.Callbacks{
 		"before_" + RoomEventAttemptReadyPhase.String(): func(_ context.Context, e *fsm.Event) {
-			if len(room.Clients) != room.RequiredPlayers {
+			if room.size() != room.RequiredPlayers {
 				e.Cancel()
 			}
 		},
 		"before_" + RoomEventAttemptGameStart.String(): func(_ context.Context, event *fsm.Event) {
-			if len(room.Clients) != room.RequiredPlayers {
+			if room.size() != room.RequiredPlayers {
 				event.Cancel()
 				return
 			}
 
-			for _, partConn := range room.Clients {
+			for _, partConn := range room.Connections() {
 				if !partConn.Ready {
 					event.Cancel()
 					return
@@ -81,13 +101,33 @@ func NewRoom(roomType pb.RoomType, stateChangeCallback func(*Room, pb.RoomState)
 			}
 		},
 		"before_" + RoomEventResetToLobby.String(): func(_ context.Context, e *fsm.Event) {
-			if len(room.Clients) != room.RequiredPlayers {
+			if room.size() != room.RequiredPlayers {
 				e.Cancel()
 			}
 		},
 		"enter_" + pb.RoomState_InGame.String(): func(_ context.Context, _ *fsm.Event) {
-			g := game.NewGame()
-			room.Game = &g
+			room.Game = game.NewGame(
+				newGameID(),
+				room.Type,
+				room.Mode,
+				room.RequiredPlayers,
+				room.notifyGame,
+			)
+
+			for _, conn := range room.Connections() {
+				err := room.Game.SetFaction(conn.ID, conn.Faction)
+				if err != nil {
+					log.Printf("Could not assign faction of client %s: %v", conn.ID, err)
+				}
+			}
+
+			room.assignObjectives()
+
+			ArchiveGame(room.Game)
+		},
+		"leave_" + pb.RoomState_InGame.String(): func(_ context.Context, _ *fsm.Event) {
+			room.Game.Close()
+			room.Game = nil
 		},
 		"enter_state": func(_ context.Context, e *fsm.Event) {
 			stateChangeCallback(room, pb.RoomState(pb.RoomState_value[e.Dst]))
@@ -101,19 +141,57 @@ func NewRoom(roomType pb.RoomType, stateChangeCallback func(*Room, pb.RoomState)
 	return room, true
 }
 
+func newGameID() string {
+	gameID, err := randomstring.GenerateString(randomstring.GenerationOptions{
+		Length:           GameIDLength,
+		DisableNumeric:   true,
+		DisableLowercase: true,
+	})
+	if err != nil {
+		log.Panicf("Error occurred while creating game id: %v", err)
+	}
+
+	return gameID
+}
+
 func GetRoom(roomID string) *Room {
 	room := roomMap[roomID]
 	return room
 }
 
+// Connections returns a snapshot of the connections of the room.
+func (room *Room) Connections() []*Connection {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	return slices.Collect(maps.Values(room.clients))
+}
+
+// connection returns the connection of the room with the id, or nil.
+func (room *Room) connection(connID string) *Connection {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	return room.clients[connID]
+}
+
+func (room *Room) size() int {
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+
+	return len(room.clients)
+}
+
 func (room *Room) AddConnection(conn *Connection) {
-	room.Clients[conn.ID] = conn
+	room.mu.Lock()
+	room.clients[conn.ID] = conn
+	room.mu.Unlock()
 
 	room.machine.Event(context.Background(), RoomEventAttemptReadyPhase.String())
 }
 
 func (room *Room) SetReady(connID string, ready bool) {
-	conn := room.Clients[connID]
+	conn := room.connection(connID)
 	if conn == nil {
 		return
 	}
@@ -123,13 +201,74 @@ func (room *Room) SetReady(connID string, ready bool) {
 	room.machine.Event(context.Background(), RoomEventAttemptGameStart.String())
 }
 
+// SetFaction selects the faction the connection plays as in the next game of the room.
+func (room *Room) SetFaction(connID string, faction pb.Faction) {
+	conn := room.connection(connID)
+	if conn == nil {
+		return
+	}
+
+	conn.Faction = faction
+}
+
+// assignObjectives hands the faction and objective of the mission of the room to every player who is not a bot.
+func (room *Room) assignObjectives() {
+	if room.Mission == nil {
+		return
+	}
+
+	err := room.Game.SetMission(room.Mission.GetId())
+	if err != nil {
+		log.Printf("Could not assign mission %s: %v", room.Mission.GetId(), err)
+	}
+
+	for _, conn := range room.Connections() {
+		if room.IsBot(conn.ID) {
+			continue
+		}
+
+		err = room.Game.SetFaction(conn.ID, room.Mission.GetFaction())
+		if err != nil {
+			log.Printf("Could not assign mission faction of client %s: %v", conn.ID, err)
+		}
+
+		err = room.Game.SetObjective(conn.ID, room.Mission.GetObjective())
+		if err != nil {
+			log.Printf("Could not assign mission objective of client %s: %v", conn.ID, err)
+		}
+	}
+}
+
 func (room *Room) RemoveConnection(connID string) {
-	delete(room.Clients, connID)
+	room.mu.Lock()
+	delete(room.clients, connID)
+	onlyBots := len(room.clients) == len(room.bots)
+	room.mu.Unlock()
 
 	room.machine.Event(context.Background(), RoomEventResetToLobby.String())
 
-	if len(room.Clients) == 0 {
+	// Bots do not keep a room alive on their own.
+	if onlyBots {
+		room.removeBots()
+	}
+
+	if room.size() == 0 {
 		// Destroy game and room.
+		if room.Game != nil {
+			room.Game.Close()
+		}
+
 		delete(roomMap, room.ID)
 	}
 }
+
+// notifyGame queues the game event for the player. It is called from the goroutine of the game, which must never
+// block on a slow reader.
+func (room *Room) notifyGame(playerID string, event *pb.GameEvent) {
+	conn := room.connection(playerID)
+	if conn == nil {
+		return
+	}
+
+	conn.SendGameEvent(event)
+}
@@ -0,0 +1,326 @@
+// Package botsdk is the reference implementation of a bot speaking the bot protocol of BotService. Bots written in
+// other languages follow the same flow: open the Play stream first, join a room through RoomService, ready up, send a
+// tree once the room is in game and answer every observation marked as their turn with an action.
+package botsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+var (
+	ErrRequestRejected = errors.New("request was rejected")
+	ErrStreamClosed    = errors.New("play stream closed before the game was over")
+)
+
+type (
+	// Agent decides how a bot plays.
+	Agent interface {
+		// Tree returns the tree the bot plays with. It is requested once the game starts.
+		Tree() *FsTree
+		// Act picks the next action from an observation marked as the bot's turn. Returning an EndTurn action yields
+		// the rest of the turn.
+		Act(observation *Observation) *Action
+	}
+
+	// Shopper is implemented by agents that buy upgrades. Shop is asked before every action, and returning nil skips
+	// the shop. The agent acts after the purchase whether it went through or not.
+	Shopper interface {
+		Shop(observation *Observation) *Purchase
+	}
+
+	// Result describes the outcome of a game as seen by a bot.
+	Result struct {
+		GameID string
+		Winner string
+		Turns  int32
+	}
+
+	// Client is a bot connected to a server through an open Play stream.
+	Client struct {
+		//nolint:containedctx // Carrying the client context across requests of the bot.
+		ctx        context.Context
+		roomClient pb.RoomServiceClient
+		stream     pb.BotService_PlayClient
+		cancel     context.CancelFunc
+		seated     chan struct{}
+		after      *Client
+		ID         string
+	}
+
+	// StrategyAgent plays with a generated tree and one of the built-in targeting strategies.
+	StrategyAgent struct {
+		Strategy Strategy
+		TreeOpts TreeGenOptions
+		Seed     uint64
+	}
+
+	pendingKind int
+)
+
+const (
+	pendingNone pendingKind = iota
+	pendingTree
+	pendingAction
+	pendingPurchase
+)
+
+// Connect opens the Play stream of a bot. The stream must be open before the bot enters a room, as the server only
+// delivers room state changes to subscribed clients.
+func Connect(conn *grpc.ClientConn, clientID string) (*Client, error) {
+	ctx := client.NewContext()
+	if clientID != "" {
+		ctx = client.NewContextWithClientID(clientID)
+	}
+
+	streamCtx, cancel := client.NewStreamContext(ctx)
+
+	stream, err := pb.NewBotServiceClient(conn).Play(streamCtx)
+	if err != nil {
+		cancel()
+
+		return nil, fmt.Errorf("could not open play stream: %w", err)
+	}
+
+	return &Client{
+		ctx:        ctx,
+		roomClient: pb.NewRoomServiceClient(conn),
+		stream:     stream,
+		cancel:     cancel,
+		seated:     make(chan struct{}),
+		after:      nil,
+		ID:         client.UnwrapContext(ctx).ClientID,
+	}, nil
+}
+
+// Close closes the Play stream, which removes the bot from its room.
+func (bot *Client) Close() {
+	bot.cancel()
+}
+
+// SeatAfter makes the bot hold back its tree until the tree of the other bot in the same room has been accepted.
+// Players take turns in the order their trees are accepted, so the other bot moves first.
+func (bot *Client) SeatAfter(other *Client) {
+	bot.after = other
+}
+
+// CreateRoom creates a room and joins it.
+func (bot *Client) CreateRoom(roomType RoomType, mode GameMode) (string, error) {
+	unaryCtx, cancel := client.NewUnaryContext(bot.ctx)
+	defer cancel()
+
+	resp, err := bot.roomClient.CreateRoom(
+		unaryCtx,
+		&pb.CreateRoomRequest{RoomType: roomType, GameMode: mode},
+	)
+	if err != nil {
+		return "", fmt.Errorf("could not create room: %w", err)
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return "", fmt.Errorf("%w: %s", ErrRequestRejected, resp.GetStatus().String())
+	}
+
+	return resp.GetRoomId(), nil
+}
+
+// JoinRoom joins an existing room.
+func (bot *Client) JoinRoom(roomID string) error {
+	unaryCtx, cancel := client.NewUnaryContext(bot.ctx)
+	defer cancel()
+
+	resp, err := bot.roomClient.JoinRoom(unaryCtx, &pb.JoinRoomRequest{RoomId: roomID})
+	if err != nil {
+		return fmt.Errorf("could not join room: %w", err)
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return fmt.Errorf("%w: %s", ErrRequestRejected, resp.GetStatus().String())
+	}
+
+	return nil
+}
+
+// Play readies the bot in its room and plays a single game with the agent until it is over.
+func (bot *Client) Play(agent Agent) (*Result, error) {
+	unaryCtx, cancel := client.NewUnaryContext(bot.ctx)
+	defer cancel()
+
+	resp, err := bot.roomClient.UpdateReady(unaryCtx, &pb.UpdateReadyRequest{Ready: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not update ready state: %w", err)
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return nil, fmt.Errorf("%w: %s", ErrRequestRejected, resp.GetStatus().String())
+	}
+
+	return bot.observe(agent)
+}
+
+//nolint:gocognit,cyclop // Observation handling is a single state machine over the triggers of the protocol.
+func (bot *Client) observe(agent Agent) (*Result, error) {
+	result := &Result{GameID: "", Winner: "", Turns: 0}
+	pending, waiting, shopped := pendingNone, false, false
+
+	for {
+		observation, err := bot.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrStreamClosed
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("play stream stopped: %w", err)
+		}
+
+		switch trigger := observation.GetTrigger().(type) {
+		case *pb.Observation_RoomState:
+			if trigger.RoomState == pb.RoomState_InGame {
+				pending = pendingTree
+
+				err = bot.sendTree(agent)
+			}
+
+		case *pb.Observation_Event:
+			event := trigger.Event
+			result.Turns = event.GetTurn()
+
+			switch {
+			case event.GetGameStarted() != nil:
+				result.GameID = event.GetGameStarted().GetGameId()
+			case event.GetGameOver() != nil:
+				result.Winner = event.GetGameOver().GetWinner()
+				return result, nil
+			case event.GetTurnStarted().GetPlayer() == bot.ID:
+				waiting = true
+			}
+
+		case *pb.Observation_Status:
+			// Keep acting while actions are accepted. A rejected action yields the rest of the turn.
+			switch pending {
+			case pendingAction:
+				waiting, shopped = trigger.Status == pb.ResponseStatus_Ok, false
+
+				if !waiting {
+					err = bot.endTurn(observation)
+				}
+			case pendingPurchase:
+				waiting, shopped = true, true
+			case pendingTree:
+				if trigger.Status == pb.ResponseStatus_Ok {
+					close(bot.seated)
+				}
+			case pendingNone:
+			}
+
+			pending = pendingNone
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Turns that start while a message of the bot is in flight are played once it has been answered.
+		if waiting && pending == pendingNone {
+			waiting = false
+
+			if observation.GetYourTurn() {
+				pending, err = bot.act(agent, observation, shopped)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// sendTree sends the tree of the agent, once the bot it is seated after has had its own tree accepted.
+func (bot *Client) sendTree(agent Agent) error {
+	if bot.after != nil {
+		select {
+		case <-bot.after.seated:
+		case <-bot.ctx.Done():
+			return fmt.Errorf("could not wait for the other bot: %w", bot.ctx.Err())
+		}
+	}
+
+	return bot.send(&pb.BotMessage{Payload: &pb.BotMessage_Tree{Tree: agent.Tree()}})
+}
+
+// act sends the next purchase of the agent, unless it has just shopped, or else its next action. Yielding in turn mode
+// ends the turn, while in real-time mode the bot simply waits for the next tick.
+func (bot *Client) act(
+	agent Agent,
+	observation *pb.Observation,
+	shopped bool,
+) (pendingKind, error) {
+	if shopper, ok := agent.(Shopper); ok && !shopped {
+		purchase := shopper.Shop(observation)
+		if purchase != nil {
+			msg := &pb.BotMessage{Payload: &pb.BotMessage_Purchase{Purchase: purchase}}
+			return pendingPurchase, bot.send(msg)
+		}
+	}
+
+	action := agent.Act(observation)
+
+	if action.GetType() == pb.ActionType_EndTurn {
+		return pendingNone, bot.endTurn(observation)
+	}
+
+	return pendingAction, bot.send(&pb.BotMessage{Payload: &pb.BotMessage_Action{Action: action}})
+}
+
+func (bot *Client) endTurn(observation *pb.Observation) error {
+	if observation.GetBoard().GetMode() != pb.GameMode_Turns || !observation.GetYourTurn() {
+		return nil
+	}
+
+	return bot.send(&pb.BotMessage{Payload: &pb.BotMessage_Action{
+		Action: &pb.Action{
+			Type:         pb.ActionType_EndTurn,
+			TargetPlayer: "",
+			TargetNode:   0,
+			TargetNodes:  nil,
+			Disguise:     nil,
+			OwnNode:      0,
+			FramedPlayer: "",
+			TargetPath:   nil,
+		},
+	}})
+}
+
+func (bot *Client) send(msg *pb.BotMessage) error {
+	err := bot.stream.Send(msg)
+	if err != nil {
+		return fmt.Errorf("could not send bot message: %w", err)
+	}
+
+	return nil
+}
+
+// NewStrategyAgent creates an agent playing with the built-in strategy of the difficulty.
+func NewStrategyAgent(difficulty BotDifficulty, seed uint64) *StrategyAgent {
+	//nolint:mnd // Tree shape of the reference bot.
+	return &StrategyAgent{
+		Strategy: game.NewStrategy(difficulty, seed),
+		TreeOpts: TreeGenOptions{Ignore: nil, VisibilityDepth: 2, Depth: 3, Width: 4},
+		Seed:     seed,
+	}
+}
+
+func (agent *StrategyAgent) Tree() *FsTree {
+	return GenerateTree(agent.Seed, agent.TreeOpts)
+}
+
+func (agent *StrategyAgent) Act(observation *Observation) *Action {
+	return agent.Strategy(observation.GetBoard())
+}
@@ -0,0 +1,89 @@
+package botsdk
+
+import (
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+// Protocol types are re-exported, as agents living outside of this module cannot import the internal packages that
+// define them.
+type (
+	Action        = pb.Action
+	ActionType    = pb.ActionType
+	Board         = pb.Board
+	BotDifficulty = pb.BotDifficulty
+	FsTree        = pb.FsTree
+	FsTreeNode    = pb.FsTreeNode
+	GameEvent     = pb.GameEvent
+	GameMode      = pb.GameMode
+	Market        = pb.Market
+	Observation   = pb.Observation
+	Purchase      = pb.Purchase
+	RoomType      = pb.RoomType
+	UpgradeType   = pb.UpgradeType
+	Visibility    = pb.Visibility
+
+	Strategy       = game.Strategy
+	TreeGenOptions = game.TreeGenOptions
+)
+
+const (
+	EndTurn        = pb.ActionType_EndTurn
+	Strike         = pb.ActionType_Strike
+	Sonar          = pb.ActionType_Sonar
+	Nuke           = pb.ActionType_Nuke
+	Infest         = pb.ActionType_Infest
+	Treat          = pb.ActionType_Treat
+	Bleeder        = pb.ActionType_Bleeder
+	DDoS           = pb.ActionType_DDoS
+	Botnet         = pb.ActionType_Botnet
+	Phisher        = pb.ActionType_Phisher
+	Backdoor       = pb.ActionType_Backdoor
+	Siphon         = pb.ActionType_Siphon
+	Ventriloquist  = pb.ActionType_Ventriloquist
+	Sniper         = pb.ActionType_Sniper
+	HealthScan     = pb.ActionType_HealthScan
+	DeepScan       = pb.ActionType_DeepScan
+	SelfAmputation = pb.ActionType_SelfAmputation
+	ToggleUpkeep   = pb.ActionType_ToggleUpkeep
+)
+
+const (
+	Regular = pb.RoomType_Regular
+	Siege   = pb.RoomType_Siege
+	Debug   = pb.RoomType_Debug
+
+	Turns    = pb.GameMode_Turns
+	RealTime = pb.GameMode_RealTime
+
+	Easy     = pb.BotDifficulty_Easy
+	Medium   = pb.BotDifficulty_Medium
+	Hard     = pb.BotDifficulty_Hard
+	Adaptive = pb.BotDifficulty_Adaptive
+)
+
+const (
+	ShieldBoost    = pb.UpgradeType_ShieldBoost
+	RechargeBoost  = pb.UpgradeType_RechargeBoost
+	NukeUnlock     = pb.UpgradeType_NukeUnlock
+	DDoSUnlock     = pb.UpgradeType_DDoSUnlock
+	SniperUnlock   = pb.UpgradeType_SniperUnlock
+	BackdoorUnlock = pb.UpgradeType_BackdoorUnlock
+
+	Corporate   = pb.Market_Corporate
+	BlackMarket = pb.Market_BlackMarket
+)
+
+const (
+	Obscured        = pb.Visibility_Obscured
+	Inferred        = pb.Visibility_Inferred
+	Probed          = pb.Visibility_Probed
+	Visible         = pb.Visibility_Visible
+	VisibleSentinel = pb.Visibility_VisibleSentinel
+)
+
+// GenerateTree generates a random tree from the seed, the same way the built-in bots do.
+func GenerateTree(seed uint64, opts TreeGenOptions) *FsTree {
+	tree := game.NewBotTree(seed, opts)
+	return &tree
+}
@@ -0,0 +1,690 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	treeGenDepth           = 8
+	treeGenWidth           = 20
+	treeGenVisibilityDepth = 8
+
+	progressUsage = "File campaign progress is saved to. Defaults to the user config"
+)
+
+var (
+	errInvalidArgument = errors.New("invalid argument")
+	errRequestRejected = errors.New("request was rejected")
+
+	//nolint:gochecknoglobals // Command table is static.
+	commands = map[string]command{
+		"create": {
+			run:     runCreate,
+			summary: "Create a room and join it. Flags: -type, -mode",
+		},
+		"join": {
+			run:     runJoin,
+			summary: "Join a room. Args: <room-id>",
+		},
+		"leave": {
+			run:     runLeave,
+			summary: "Leave the joined room",
+		},
+		"ready": {
+			run:     runReady,
+			summary: "Update the ready state in the joined room. Flags: -ready",
+		},
+		"faction": {
+			run:     runFaction,
+			summary: "Select the faction played in the joined room. Args: <faction>",
+		},
+		"bots": {
+			run: runBots,
+			summary: "Fill the empty slots of the joined room with bots. " +
+				"Flags: -difficulty, -adaptation",
+		},
+		"missions": {
+			run:     runMissions,
+			summary: "List the campaign missions and the saved progress. Flags: -progress",
+		},
+		"mission": {
+			run: runMission,
+			summary: "Start an unlocked campaign mission against bots. Flags: -progress. " +
+				"Args: <mission-id>",
+		},
+		"subscribe": {
+			run: runSubscribe,
+			summary: "Stream room and game events. Flags: -root, -depth, -width, -mission, " +
+				"-progress",
+		},
+		"board": {
+			run:     runBoard,
+			summary: "Print the board as visible to the client",
+		},
+		"act": {
+			run: runAct,
+			summary: "Submit an action. Flags: -own-node, -framed, -path. " +
+				"Args: <action-type> [<target-player> <target-node>[,<target-node>...]]",
+		},
+		"buy": {
+			run:     runBuy,
+			summary: "Purchase an upgrade. Flags: -market. Args: <upgrade> [<own-node>]",
+		},
+	}
+)
+
+type (
+	CLI struct {
+		//nolint:containedctx // Carrying the client context across commands.
+		ctx        context.Context
+		roomClient pb.RoomServiceClient
+		gameClient pb.GameServiceClient
+		out        *printer
+	}
+
+	command struct {
+		run     func(cli *CLI, args []string) error
+		summary string
+	}
+)
+
+// respond prints the response and fails the command if the server did not accept the request.
+func (cli *CLI) respond(label string, resp proto.Message, status pb.ResponseStatus) error {
+	err := cli.out.print(label, resp)
+	if err != nil {
+		return err
+	}
+
+	if status != pb.ResponseStatus_Ok {
+		return fmt.Errorf("%w: %s", errRequestRejected, status.String())
+	}
+
+	return nil
+}
+
+func enumNames(names map[int32]string) string {
+	values := slices.Sorted(maps.Keys(names))
+
+	sorted := make([]string, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, names[value])
+	}
+
+	return strings.Join(sorted, ", ")
+}
+
+func runCreate(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("create", flag.ContinueOnError)
+	roomType := flags.String(
+		"type",
+		pb.RoomType_Regular.String(),
+		"Room type, one of: "+enumNames(pb.RoomType_name),
+	)
+	mode := flags.String(
+		"mode",
+		pb.GameMode_Turns.String(),
+		"Game mode, one of: "+enumNames(pb.GameMode_name),
+	)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	roomTypeValue, ok := pb.RoomType_value[*roomType]
+	if !ok {
+		return fmt.Errorf("%w: unknown room type %s", errInvalidArgument, *roomType)
+	}
+
+	modeValue, ok := pb.GameMode_value[*mode]
+	if !ok {
+		return fmt.Errorf("%w: unknown game mode %s", errInvalidArgument, *mode)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.CreateRoom(unaryCtx, &pb.CreateRoomRequest{
+		RoomType: pb.RoomType(roomTypeValue),
+		GameMode: pb.GameMode(modeValue),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create room: %w", err)
+	}
+
+	return cli.respond("createRoom", resp, resp.GetStatus())
+}
+
+func runJoin(cli *CLI, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: expected a room id", errInvalidArgument)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.JoinRoom(unaryCtx, &pb.JoinRoomRequest{RoomId: args[0]})
+	if err != nil {
+		return fmt.Errorf("could not join room: %w", err)
+	}
+
+	return cli.respond("joinRoom", resp, resp.GetStatus())
+}
+
+func runLeave(cli *CLI, _ []string) error {
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.LeaveRoom(unaryCtx, &pb.LeaveRoomRequest{})
+	if err != nil {
+		return fmt.Errorf("could not leave room: %w", err)
+	}
+
+	return cli.respond("leaveRoom", resp, resp.GetStatus())
+}
+
+func runReady(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("ready", flag.ContinueOnError)
+	ready := flags.Bool("ready", true, "Ready state to set")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.UpdateReady(unaryCtx, &pb.UpdateReadyRequest{Ready: *ready})
+	if err != nil {
+		return fmt.Errorf("could not update ready state: %w", err)
+	}
+
+	return cli.respond("updateReady", resp, resp.GetStatus())
+}
+
+func runFaction(cli *CLI, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf(
+			"%w: expected a faction, one of: %s",
+			errInvalidArgument,
+			enumNames(pb.Faction_name),
+		)
+	}
+
+	faction, ok := pb.Faction_value[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown faction %s", errInvalidArgument, args[0])
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.SelectFaction(
+		unaryCtx,
+		&pb.SelectFactionRequest{Faction: pb.Faction(faction)},
+	)
+	if err != nil {
+		return fmt.Errorf("could not select faction: %w", err)
+	}
+
+	return cli.respond("selectFaction", resp, resp.GetStatus())
+}
+
+func runBots(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("bots", flag.ContinueOnError)
+	difficulty := flags.String(
+		"difficulty",
+		pb.BotDifficulty_Medium.String(),
+		"Bot difficulty, one of: "+enumNames(pb.BotDifficulty_name),
+	)
+	rate := flags.Float64(
+		"adaptation",
+		0,
+		"Share of the memory of Adaptive bots taken over by every play, or the default when 0",
+	)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	difficultyValue, ok := pb.BotDifficulty_value[*difficulty]
+	if !ok {
+		return fmt.Errorf("%w: unknown bot difficulty %s", errInvalidArgument, *difficulty)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.FillWithBots(
+		unaryCtx,
+		&pb.FillWithBotsRequest{
+			Difficulty:     pb.BotDifficulty(difficultyValue),
+			AdaptationRate: *rate,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not fill room with bots: %w", err)
+	}
+
+	return cli.respond("fillWithBots", resp, resp.GetStatus())
+}
+
+// progressPath resolves the file campaign progress is saved to, defaulting to the config of the user.
+func progressPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	path, err := client.DefaultProgressPath()
+	if err != nil {
+		return "", fmt.Errorf("could not resolve campaign progress: %w", err)
+	}
+
+	return path, nil
+}
+
+func runMissions(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("missions", flag.ContinueOnError)
+	path := flags.String("progress", "", progressUsage)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	*path, err = progressPath(*path)
+	if err != nil {
+		return err
+	}
+
+	progress, err := client.LoadProgress(*path)
+	if err != nil {
+		return err
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.ListMissions(unaryCtx, &pb.ListMissionsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not list missions: %w", err)
+	}
+
+	err = cli.respond("listMissions", resp, resp.GetStatus())
+	if err != nil {
+		return err
+	}
+
+	return cli.out.print("campaignProgress", progress)
+}
+
+func runMission(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("mission", flag.ContinueOnError)
+	path := flags.String("progress", "", progressUsage)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected a mission id", errInvalidArgument)
+	}
+
+	missionID := flags.Arg(0)
+
+	*path, err = progressPath(*path)
+	if err != nil {
+		return err
+	}
+
+	progress, err := client.LoadProgress(*path)
+	if err != nil {
+		return err
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	missions, err := cli.roomClient.ListMissions(unaryCtx, &pb.ListMissionsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not list missions: %w", err)
+	}
+
+	if !client.Unlocked(missions.GetCampaign(), progress, missionID) {
+		return fmt.Errorf("%w: %s", client.ErrMissionLocked, missionID)
+	}
+
+	resp, err := cli.roomClient.StartMission(
+		unaryCtx,
+		&pb.StartMissionRequest{MissionId: missionID},
+	)
+	if err != nil {
+		return fmt.Errorf("could not start mission: %w", err)
+	}
+
+	return cli.respond("startMission", resp, resp.GetStatus())
+}
+
+func runBoard(cli *CLI, _ []string) error {
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.gameClient.GetBoard(unaryCtx, &pb.GetBoardRequest{})
+	if err != nil {
+		return fmt.Errorf("could not get board: %w", err)
+	}
+
+	return cli.respond("board", resp, resp.GetStatus())
+}
+
+func runAct(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("act", flag.ContinueOnError)
+	ownNode := flags.Int("own-node", 0, "Own node the action works with")
+	framed := flags.String("framed", "", "Player the next action of the target appears to be from")
+	path := flags.String("path", "", "Slash separated labels leading to the target node")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	args = flags.Args()
+
+	if len(args) == 0 {
+		return fmt.Errorf(
+			"%w: expected an action type, one of: %s",
+			errInvalidArgument,
+			enumNames(pb.ActionType_name),
+		)
+	}
+
+	actionType, ok := pb.ActionType_value[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown action type %s", errInvalidArgument, args[0])
+	}
+
+	action := &pb.Action{
+		Type:         pb.ActionType(actionType),
+		TargetPlayer: "",
+		TargetNode:   0,
+		TargetNodes:  nil,
+		Disguise:     nil,
+		OwnNode:      int32(*ownNode), //nolint:gosec // Node ids on the command line are small.
+		FramedPlayer: *framed,
+		TargetPath:   nil,
+	}
+
+	if *path != "" {
+		action.TargetPath = strings.Split(*path, "/")
+	}
+
+	if len(args) > 1 {
+		if len(args) != 3 { //nolint:mnd // Action type followed by the target player and node.
+			return fmt.Errorf("%w: expected a target player and a target node", errInvalidArgument)
+		}
+
+		// Actions aimed at several nodes take them as a comma separated list.
+		for field := range strings.SplitSeq(args[2], ",") {
+			node, err := strconv.ParseInt(field, 10, 32)
+			if err != nil {
+				return fmt.Errorf("%w: target node: %w", errInvalidArgument, err)
+			}
+
+			action.TargetNodes = append(action.TargetNodes, int32(node))
+		}
+
+		action.TargetPlayer, action.TargetNode = args[1], action.GetTargetNodes()[0]
+
+		if len(action.GetTargetNodes()) == 1 {
+			action.TargetNodes = nil
+		}
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.gameClient.SubmitAction(unaryCtx, &pb.SubmitActionRequest{Action: action})
+	if err != nil {
+		return fmt.Errorf("could not submit action: %w", err)
+	}
+
+	return cli.respond("submitAction", resp, resp.GetStatus())
+}
+
+func runBuy(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("buy", flag.ContinueOnError)
+	market := flags.String(
+		"market",
+		pb.Market_Corporate.String(),
+		"Market to buy from, one of: "+enumNames(pb.Market_name),
+	)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	args = flags.Args()
+
+	if len(args) == 0 || len(args) > 2 { //nolint:mnd // Upgrade followed by an optional own node.
+		return fmt.Errorf(
+			"%w: expected an upgrade, one of: %s",
+			errInvalidArgument,
+			enumNames(pb.UpgradeType_name),
+		)
+	}
+
+	upgrade, ok := pb.UpgradeType_value[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown upgrade %s", errInvalidArgument, args[0])
+	}
+
+	marketValue, ok := pb.Market_value[*market]
+	if !ok {
+		return fmt.Errorf("%w: unknown market %s", errInvalidArgument, *market)
+	}
+
+	purchase := &pb.Purchase{
+		Upgrade: pb.UpgradeType(upgrade),
+		Market:  pb.Market(marketValue),
+		Node:    0,
+	}
+
+	if len(args) == 2 { //nolint:mnd // Upgrade followed by an own node.
+		node, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("%w: own node: %w", errInvalidArgument, err)
+		}
+
+		purchase.Node = int32(node)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.gameClient.Purchase(unaryCtx, &pb.PurchaseRequest{Purchase: purchase})
+	if err != nil {
+		return fmt.Errorf("could not purchase upgrade: %w", err)
+	}
+
+	return cli.respond("purchase", resp, resp.GetStatus())
+}
+
+func runSubscribe(cli *CLI, args []string) error {
+	flags := flag.NewFlagSet("subscribe", flag.ContinueOnError)
+	root := flags.String("root", ".", "Directory to generate the published tree from")
+	opts := game.TreeGenOptions{
+		Ignore:          game.DefaultTreeGenIgnores[:],
+		VisibilityDepth: treeGenVisibilityDepth,
+		Depth:           0,
+		Width:           0,
+	}
+	flags.IntVar(&opts.Depth, "depth", treeGenDepth, "Maximum depth of the published tree")
+	flags.IntVar(&opts.Width, "width", treeGenWidth, "Maximum children per node of the tree")
+	missionID := flags.String("mission", "", "Campaign mission to save as completed when it is won")
+	path := flags.String("progress", "", progressUsage)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidArgument, err)
+	}
+
+	*path, err = progressPath(*path)
+	if err != nil {
+		return err
+	}
+
+	mission, err := cli.findMission(*missionID)
+	if err != nil {
+		return err
+	}
+
+	streamCtx, cancel := client.NewStreamContext(cli.ctx)
+	defer cancel()
+
+	roomStream, err := cli.roomClient.SubscribeMessages(streamCtx, &pb.SubscribeMessagesRequest{})
+	if err != nil {
+		return fmt.Errorf("could not subscribe to room messages: %w", err)
+	}
+
+	gameStream, err := cli.gameClient.SubscribeGameEvents(
+		streamCtx,
+		&pb.SubscribeGameEventsRequest{},
+	)
+	if err != nil {
+		return fmt.Errorf("could not subscribe to game events: %w", err)
+	}
+
+	errs := make(chan error, 2) //nolint:mnd // One result per stream.
+
+	go func() {
+		errs <- cli.printRoomMessages(roomStream, *root, opts)
+	}()
+
+	go func() {
+		errs <- cli.printGameEvents(gameStream, mission, *path)
+	}()
+
+	return <-errs
+}
+
+func (cli *CLI) printRoomMessages(
+	stream pb.RoomService_SubscribeMessagesClient,
+	root string,
+	opts game.TreeGenOptions,
+) error {
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("room message stream stopped: %w", err)
+		}
+
+		err = cli.out.print("roomState", msg)
+		if err != nil {
+			return err
+		}
+
+		if msg.GetType() == pb.RoomState_InGame {
+			err := cli.publishTree(root, opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// findMission looks up the campaign mission with the id, if any.
+func (cli *CLI) findMission(missionID string) (*pb.Mission, error) {
+	if missionID == "" {
+		return nil, nil //nolint:nilnil // Subscribing outside of missions is not an error.
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.roomClient.ListMissions(unaryCtx, &pb.ListMissionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("could not list missions: %w", err)
+	}
+
+	mission := client.FindMission(resp.GetCampaign(), missionID)
+	if mission == nil {
+		return nil, fmt.Errorf("%w: unknown mission %s", errInvalidArgument, missionID)
+	}
+
+	return mission, nil
+}
+
+// printGameEvents prints the game events of the stream. Winning the game completes the mission, if any, in the
+// campaign progress saved at the path.
+func (cli *CLI) printGameEvents(
+	stream pb.GameService_SubscribeGameEventsClient,
+	mission *pb.Mission,
+	path string,
+) error {
+	clientID := client.UnwrapContext(cli.ctx).ClientID
+	gameID := ""
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("game event stream stopped: %w", err)
+		}
+
+		err = cli.out.print("gameEvent", event)
+		if err != nil {
+			return err
+		}
+
+		if started := event.GetGameStarted(); started != nil {
+			gameID = started.GetGameId()
+		}
+
+		if mission == nil || event.GetGameOver().GetWinner() != clientID {
+			continue
+		}
+
+		err = client.CompleteMission(cli.ctx, cli.gameClient, path, mission, gameID)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (cli *CLI) publishTree(root string, opts game.TreeGenOptions) error {
+	tree := game.NewFsTree(root, opts)
+
+	unaryCtx, cancel := client.NewUnaryContext(cli.ctx)
+	defer cancel()
+
+	resp, err := cli.gameClient.AddPlayer(unaryCtx, &pb.AddPlayerRequest{Tree: &tree})
+	if err != nil {
+		return fmt.Errorf("could not publish tree: %w", err)
+	}
+
+	return cli.respond("addPlayer", resp, resp.GetStatus())
+}
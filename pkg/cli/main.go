@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	defaultAddress = "localhost:50051"
+	exitUsage      = 2
+)
+
+type cliOptions struct {
+	address  string
+	clientID string
+	format   string
+	tls      bool
+}
+
+func usage(flags *flag.FlagSet) func() {
+	return func() {
+		out := flags.Output()
+
+		fmt.Fprintf(out, "Usage: %s [flags] <command> [args]\n\nCommands:\n", flags.Name())
+
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+
+		slices.Sort(names)
+
+		for _, name := range names {
+			fmt.Fprintf(out, "  %-10s %s\n", name, commands[name].summary)
+		}
+
+		fmt.Fprintln(out, "\nFlags:")
+		flags.PrintDefaults()
+	}
+}
+
+func run(cmd command, opts cliOptions, args []string) error {
+	conn, err := client.Dial(opts.address, opts.tls)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := client.NewContext()
+	if opts.clientID != "" {
+		ctx = client.NewContextWithClientID(opts.clientID)
+	}
+
+	log.Printf("Acting as client %s", client.UnwrapContext(ctx).ClientID)
+
+	cli := &CLI{
+		ctx:        ctx,
+		roomClient: pb.NewRoomServiceClient(conn),
+		gameClient: pb.NewGameServiceClient(conn),
+		out:        newPrinter(os.Stdout, opts.format),
+	}
+
+	return cmd.run(cli, args)
+}
+
+func main() {
+	opts := cliOptions{address: "", clientID: "", format: "", tls: false}
+
+	flags := flag.NewFlagSet("nukeship-cli", flag.ExitOnError)
+	flags.StringVar(&opts.address, "addr", defaultAddress, "Address of the game server")
+	flags.BoolVar(&opts.tls, "tls", false, "Connect to the game server over TLS")
+	flags.StringVar(&opts.clientID, "client-id", "", "Client id to act as across invocations")
+	flags.StringVar(
+		&opts.format,
+		"format",
+		formatText,
+		"Output format for responses and events, one of: "+strings.Join(formats, ", "),
+	)
+	flags.Usage = usage(flags)
+
+	err := flags.Parse(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Could not parse flags: %v", err)
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok || !slices.Contains(formats, opts.format) {
+		flags.Usage()
+		os.Exit(exitUsage)
+	}
+
+	err = run(cmd, opts, flags.Args()[1:])
+	if err != nil {
+		log.Fatalf("Command %s failed: %v", flags.Arg(0), err)
+	}
+}
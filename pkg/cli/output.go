@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+//nolint:gochecknoglobals // Supported output formats are static.
+var formats = []string{formatText, formatJSON}
+
+// printer writes one line per message, labelled with the kind of message. JSON output wraps each message in an
+// object keyed by its label so that streams can be consumed line by line.
+type printer struct {
+	out  io.Writer
+	mu   sync.Mutex
+	json bool
+}
+
+func newPrinter(out io.Writer, format string) *printer {
+	return &printer{out: out, mu: sync.Mutex{}, json: format == formatJSON}
+}
+
+func (p *printer) print(label string, msg proto.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.json {
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("could not encode %s: %w", label, err)
+		}
+
+		_, err = fmt.Fprintf(p.out, "{%q:%s}\n", label, data)
+		if err != nil {
+			return fmt.Errorf("could not print %s: %w", label, err)
+		}
+
+		return nil
+	}
+
+	_, err := fmt.Fprintf(p.out, "%s: %v\n", label, msg)
+	if err != nil {
+		return fmt.Errorf("could not print %s: %w", label, err)
+	}
+
+	return nil
+}
@@ -6,12 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
-	"time"
-
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/keepalive"
+	"sync/atomic"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
@@ -29,12 +24,6 @@ const (
 	treeGenVisibilityDepth int = 8
 )
 
-//nolint:gochecknoglobals,mnd // Configuration only kept at the time of first initialization.
-var KeepAliveClientParameters = keepalive.ClientParameters{
-	Time:    30 * time.Second,
-	Timeout: 10 * time.Second,
-}
-
 type (
 	Event string
 )
@@ -43,11 +32,13 @@ type WailsApp struct {
 	//nolint:containedctx // Wails enforces usage of contexts within structs for binding.
 	wailsCtx context.Context
 	//nolint:containedctx // Wails enforces usage of contexts within structs for binding.
-	configCtx  context.Context
-	RoomClient pb.RoomServiceClient
-	GameClient pb.GameServiceClient
-	roomState  *pb.RoomState
-	connected  bool
+	configCtx   context.Context
+	RoomClient  pb.RoomServiceClient
+	GameClient  pb.GameServiceClient
+	roomState   *pb.RoomState
+	stopReplay  context.CancelFunc
+	replaySpeed atomic.Uint64
+	connected   bool
 }
 
 func (app *WailsApp) GetRoomState() *pb.RoomState {
@@ -87,7 +78,7 @@ func (app *WailsApp) CreateRoom(roomType pb.RoomType) (string, error) {
 
 	resp, err := app.RoomClient.CreateRoom(
 		unaryCtx,
-		&pb.CreateRoomRequest{RoomType: processRoomType(roomType)},
+		&pb.CreateRoomRequest{RoomType: processRoomType(roomType), GameMode: pb.GameMode_Turns},
 	)
 	if err != nil {
 		runtime.LogErrorf(app.wailsCtx, "Could not create room: %v", err)
@@ -132,6 +123,43 @@ func (app *WailsApp) LeaveRoom() bool {
 	return resp.GetStatus() == pb.ResponseStatus_Ok
 }
 
+// FillWithBots adds bots of the difficulty to every empty slot of the joined room.
+func (app *WailsApp) FillWithBots(difficulty pb.BotDifficulty) bool {
+	unaryCtx, cancel := client.NewUnaryContext(app.configCtx)
+	defer cancel()
+
+	resp, err := app.RoomClient.FillWithBots(
+		unaryCtx,
+		&pb.FillWithBotsRequest{Difficulty: difficulty, AdaptationRate: 0},
+	)
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not fill room with bots: %v", err)
+
+		return false
+	}
+
+	runtime.LogDebugf(app.wailsCtx, "Added bots to room: %v", resp.GetBotIds())
+
+	return resp.GetStatus() == pb.ResponseStatus_Ok
+}
+
+// SelectFaction selects the faction played in the next game of the joined room.
+func (app *WailsApp) SelectFaction(faction pb.Faction) bool {
+	unaryCtx, cancel := client.NewUnaryContext(app.configCtx)
+	defer cancel()
+
+	resp, err := app.RoomClient.SelectFaction(unaryCtx, &pb.SelectFactionRequest{Faction: faction})
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not select faction: %v", err)
+
+		return false
+	}
+
+	runtime.LogDebugf(app.wailsCtx, "Selected faction %s: %s", faction, resp.GetStatus().String())
+
+	return resp.GetStatus() == pb.ResponseStatus_Ok
+}
+
 func processRoomType(roomType pb.RoomType) pb.RoomType {
 	if Config.DebugRoom {
 		return pb.RoomType_Debug
@@ -142,14 +170,18 @@ func processRoomType(roomType pb.RoomType) pb.RoomType {
 
 func newWailsApp() *WailsApp {
 	app := &WailsApp{
-		wailsCtx:   nil,
-		configCtx:  nil,
-		RoomClient: nil,
-		GameClient: nil,
-		roomState:  nil,
-		connected:  false,
+		wailsCtx:    nil,
+		configCtx:   nil,
+		RoomClient:  nil,
+		GameClient:  nil,
+		roomState:   nil,
+		stopReplay:  nil,
+		replaySpeed: atomic.Uint64{},
+		connected:   false,
 	}
 
+	app.SetReplaySpeed(defaultReplaySpeed)
+
 	return app
 }
 
@@ -160,19 +192,9 @@ func (app *WailsApp) setAppContext(wailsCtx, configCtx context.Context) {
 }
 
 func (app *WailsApp) initGrpcClients() {
-	var creds credentials.TransportCredentials
-
-	if Config.EnableTLS {
-		creds = credentials.NewClientTLSFromCert(nil, "")
-	} else {
-		creds = insecure.NewCredentials()
-	}
+	address := Config.ServerHost + ":" + strconv.Itoa(Config.ServerPort)
 
-	conn, err := grpc.NewClient(
-		Config.ServerHost+":"+strconv.Itoa(Config.ServerPort),
-		grpc.WithTransportCredentials(creds),
-		grpc.WithKeepaliveParams(KeepAliveClientParameters),
-	)
+	conn, err := client.Dial(address, Config.EnableTLS)
 	if err != nil {
 		runtime.LogErrorf(app.wailsCtx, "Could not connect: %v", err)
 	}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/pb"
+)
+
+// ListMissions lists the missions of the campaign in the order they are meant to be played in.
+func (app *WailsApp) ListMissions() *pb.Campaign {
+	unaryCtx, cancel := client.NewUnaryContext(app.configCtx)
+	defer cancel()
+
+	resp, err := app.RoomClient.ListMissions(unaryCtx, &pb.ListMissionsRequest{})
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not list missions: %v", err)
+
+		return nil
+	}
+
+	return resp.GetCampaign()
+}
+
+// GetCampaignProgress returns the campaign progress saved on this machine.
+func (app *WailsApp) GetCampaignProgress() *pb.CampaignProgress {
+	progress, err := loadProgress()
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not load campaign progress: %v", err)
+
+		return nil
+	}
+
+	return progress
+}
+
+// StartMission creates the room of the mission against its bot opponent and returns the room id. Missions are
+// unlocked by completing the earlier missions of the campaign.
+func (app *WailsApp) StartMission(missionID string) (string, error) {
+	progress, err := loadProgress()
+	if err != nil {
+		return "", fmt.Errorf("could not load campaign progress: %w", err)
+	}
+
+	if !client.Unlocked(app.ListMissions(), progress, missionID) {
+		return "", fmt.Errorf("%w: %s", client.ErrMissionLocked, missionID)
+	}
+
+	unaryCtx, cancel := client.NewUnaryContext(app.configCtx)
+	defer cancel()
+
+	resp, err := app.RoomClient.StartMission(
+		unaryCtx,
+		&pb.StartMissionRequest{MissionId: missionID},
+	)
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not start mission: %v", err)
+
+		return "", fmt.Errorf("could not start mission: %w", err)
+	}
+
+	runtime.LogDebugf(app.wailsCtx, "Started mission %s: %s", missionID, resp.GetStatus().String())
+
+	return resp.GetRoomId(), nil
+}
+
+// CompleteMission saves the mission as completed in the campaign progress on this machine, once the server confirms
+// that the game was won.
+func (app *WailsApp) CompleteMission(missionID, gameID string) bool {
+	mission := client.FindMission(app.ListMissions(), missionID)
+	if mission == nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not find mission %s", missionID)
+
+		return false
+	}
+
+	path, err := client.DefaultProgressPath()
+	if err == nil {
+		err = client.CompleteMission(app.configCtx, app.GameClient, path, mission, gameID)
+	}
+
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not save campaign progress: %v", err)
+
+		return false
+	}
+
+	return true
+}
+
+func loadProgress() (*pb.CampaignProgress, error) {
+	path, err := client.DefaultProgressPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.LoadProgress(path)
+}
@@ -4,6 +4,7 @@ import toast, { Toaster } from "solid-toast"
 import useConnection from "@hooks/useConnection"
 import GameBoard from "@pages/GameBoard"
 import Landing from "@pages/Landing"
+import Replay from "@pages/Replay"
 import WaitingRoom from "@pages/WaitingRoom"
 
 const App: VoidComponent = () => {
@@ -37,6 +38,7 @@ const App: VoidComponent = () => {
                 <Route path="/" component={() => <Landing />} />
                 <Route path="/room/:code" component={() => <WaitingRoom />} />
                 <Route path="/game/:code" component={() => <GameBoard />} />
+                <Route path="/replay" component={() => <Replay />} />
             </Router>
             <Toaster
                 toastOptions={{
@@ -96,6 +96,17 @@ export const CONTENT = {
                 speaker: "Unified Systems Command, CNS-3008: Critical Node Survival Doctrine, October, 3008, pp. 14-19",
             },
         },
+        UPKEEP: {
+            title: "Upkeep",
+            icon: "⟳" as const,
+            description:
+                "Gives up the node's own shield recharge and shares it among its parent, siblings and children.",
+            tip: "Toggle upkeep on a node during placement or mid-game to keep the nodes around a weak spot topped up.",
+            lore: {
+                quote: "A node that only guards itself guards nothing worth keeping.",
+                speaker: "Unified Systems Command, CNS-3011: Distributed Maintenance Doctrine, March, 3011, pp. 3",
+            },
+        },
     } satisfies Record<string, DescriptiveContent>,
     ATTACKS: {
         TARGET: {
@@ -123,6 +134,33 @@ export const CONTENT = {
             },
         },
     } satisfies Record<string, ActionContent & DescriptiveContent>,
+    STATUS_EFFECTS: {
+        INFESTATION: {
+            title: "Infestation",
+            icon: "🦠" as const,
+            description: "Suppresses the node's income and spreads to its neighbors every turn.",
+        },
+        DATA_BLEED: {
+            title: "Data Bleed",
+            icon: "🩸" as const,
+            description: "Slows the node's shield recharge. Stacks up to a limit and refreshes its duration.",
+        },
+        MASKED: {
+            title: "Masked",
+            icon: "🎭" as const,
+            description: "Shows the node to opponents under a disguise.",
+        },
+        BACKDOOR_ACCESS: {
+            title: "Backdoor Access",
+            icon: "🚪" as const,
+            description: "Lets an opponent siphon power from the node until the backdoor is detected.",
+        },
+        POWER_SIPHON: {
+            title: "Power Siphon",
+            icon: "🔌" as const,
+            description: "Drains the node's power to an opponent every turn.",
+        },
+    } satisfies Record<string, BaseContent>,
     MISC: {
         REMOVE_PLAN: {
             title: "Remove Plan",
@@ -1,8 +1,11 @@
+import { StatusEffectType } from "@constants/types"
 import { RawDataStream } from "@game/tree"
 
 export const ExampleFS: RawDataStream = {
     label: "NT",
     sentinel: true,
+    upkeep: false,
+    effects: [],
     shield: 10,
     power: 20,
     rechargeRate: 5,
@@ -10,6 +13,8 @@ export const ExampleFS: RawDataStream = {
         {
             label: "C:",
             sentinel: true,
+            upkeep: false,
+            effects: [],
             shield: 10,
             power: 20,
             rechargeRate: 5,
@@ -17,6 +22,8 @@ export const ExampleFS: RawDataStream = {
                 {
                     label: "Windows",
                     sentinel: false,
+                    upkeep: true,
+                    effects: [],
                     shield: 10,
                     power: 20,
                     rechargeRate: 5,
@@ -24,6 +31,8 @@ export const ExampleFS: RawDataStream = {
                         {
                             label: "System32",
                             sentinel: false,
+                            upkeep: false,
+                            effects: [{ type: StatusEffectType.DATA_BLEED, remaining: 2, stacks: 1 }],
                             shield: 10,
                             power: 20,
                             rechargeRate: 5,
@@ -31,6 +40,8 @@ export const ExampleFS: RawDataStream = {
                                 {
                                     label: "Microsoft",
                                     sentinel: false,
+                                    upkeep: false,
+                                    effects: [],
                                     shield: 10,
                                     power: 20,
                                     rechargeRate: 5,
@@ -38,6 +49,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Azure",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -45,6 +58,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Device.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -53,6 +68,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Preface.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -61,6 +78,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "AzureAD.exe",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -71,6 +90,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Defender",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -78,6 +99,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Defense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -86,6 +109,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "NoDefense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -94,6 +119,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "TestingKPS.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -106,6 +133,8 @@ export const ExampleFS: RawDataStream = {
                                 {
                                     label: "Avast",
                                     sentinel: false,
+                                    upkeep: false,
+                                    effects: [],
                                     shield: 10,
                                     power: 20,
                                     rechargeRate: 5,
@@ -113,6 +142,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Azure",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -120,6 +151,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Device.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -128,6 +161,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Preface.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -136,6 +171,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "AzureAD.exe",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -146,6 +183,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Defender",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -153,6 +192,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Defense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -161,6 +202,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "NoDefense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -169,6 +212,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "TestingKPS.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -181,6 +226,8 @@ export const ExampleFS: RawDataStream = {
                                 {
                                     label: "Tiberius",
                                     sentinel: false,
+                                    upkeep: false,
+                                    effects: [],
                                     shield: 10,
                                     power: 20,
                                     rechargeRate: 5,
@@ -188,6 +235,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Azure",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -195,6 +244,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Device.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -203,6 +254,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Preface.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -211,6 +264,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "AzureAD.exe",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -221,6 +276,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Defender",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -228,6 +285,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Defense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -236,6 +295,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "NoDefense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -244,6 +305,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "TestingKPS.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -256,6 +319,8 @@ export const ExampleFS: RawDataStream = {
                                 {
                                     label: "Taurus",
                                     sentinel: false,
+                                    upkeep: false,
+                                    effects: [],
                                     shield: 10,
                                     power: 20,
                                     rechargeRate: 5,
@@ -263,6 +328,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Azure",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -270,6 +337,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Device.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -278,6 +347,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Preface.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -286,6 +357,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "AzureAD.exe",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -296,6 +369,8 @@ export const ExampleFS: RawDataStream = {
                                         {
                                             label: "Defender",
                                             sentinel: false,
+                                            upkeep: false,
+                                            effects: [],
                                             shield: 10,
                                             power: 20,
                                             rechargeRate: 5,
@@ -303,6 +378,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "Defense.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -311,6 +388,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "NoDefense",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -318,6 +397,8 @@ export const ExampleFS: RawDataStream = {
                                                         {
                                                             label: "Avast",
                                                             sentinel: false,
+                                                            upkeep: false,
+                                                            effects: [],
                                                             shield: 10,
                                                             power: 20,
                                                             rechargeRate: 5,
@@ -325,6 +406,8 @@ export const ExampleFS: RawDataStream = {
                                                                 {
                                                                     label: "Azure",
                                                                     sentinel: false,
+                                                                    upkeep: false,
+                                                                    effects: [],
                                                                     shield: 10,
                                                                     power: 20,
                                                                     rechargeRate: 5,
@@ -332,6 +415,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "Device.dll",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -340,6 +425,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "Preface.dll",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -348,6 +435,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "AzureAD.exe",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -358,6 +447,8 @@ export const ExampleFS: RawDataStream = {
                                                                 {
                                                                     label: "Defender",
                                                                     sentinel: false,
+                                                                    upkeep: false,
+                                                                    effects: [],
                                                                     shield: 10,
                                                                     power: 20,
                                                                     rechargeRate: 5,
@@ -365,6 +456,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "Defense.dll",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -373,6 +466,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "NoDefense.dll",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -381,6 +476,8 @@ export const ExampleFS: RawDataStream = {
                                                                         {
                                                                             label: "TestingKPS.dll",
                                                                             sentinel: false,
+                                                                            upkeep: false,
+                                                                            effects: [],
                                                                             shield: 10,
                                                                             power: 20,
                                                                             rechargeRate: 5,
@@ -395,6 +492,8 @@ export const ExampleFS: RawDataStream = {
                                                 {
                                                     label: "TestingKPS.dll",
                                                     sentinel: false,
+                                                    upkeep: false,
+                                                    effects: [],
                                                     shield: 10,
                                                     power: 20,
                                                     rechargeRate: 5,
@@ -413,6 +512,8 @@ export const ExampleFS: RawDataStream = {
         {
             label: "D:",
             sentinel: false,
+            upkeep: false,
+            effects: [],
             shield: 10,
             power: 20,
             rechargeRate: 5,
@@ -420,6 +521,8 @@ export const ExampleFS: RawDataStream = {
                 {
                     label: "Dev",
                     sentinel: false,
+                    upkeep: false,
+                    effects: [],
                     shield: 10,
                     power: 20,
                     rechargeRate: 5,
@@ -427,6 +530,8 @@ export const ExampleFS: RawDataStream = {
                         {
                             label: "api.dll",
                             sentinel: false,
+                            upkeep: false,
+                            effects: [],
                             shield: 10,
                             power: 20,
                             rechargeRate: 5,
@@ -439,6 +544,8 @@ export const ExampleFS: RawDataStream = {
         {
             label: "E:",
             sentinel: false,
+            upkeep: false,
+            effects: [],
             shield: 10,
             power: 20,
             rechargeRate: 5,
@@ -446,6 +553,8 @@ export const ExampleFS: RawDataStream = {
                 {
                     label: "Dev",
                     sentinel: false,
+                    upkeep: false,
+                    effects: [],
                     shield: 10,
                     power: 20,
                     rechargeRate: 5,
@@ -453,6 +562,8 @@ export const ExampleFS: RawDataStream = {
                         {
                             label: "api.dll",
                             sentinel: false,
+                            upkeep: false,
+                            effects: [],
                             shield: 10,
                             power: 20,
                             rechargeRate: 5,
@@ -465,6 +576,8 @@ export const ExampleFS: RawDataStream = {
         {
             label: "F:",
             sentinel: false,
+            upkeep: false,
+            effects: [],
             shield: 10,
             power: 20,
             rechargeRate: 5,
@@ -472,6 +585,8 @@ export const ExampleFS: RawDataStream = {
                 {
                     label: "Dev",
                     sentinel: false,
+                    upkeep: false,
+                    effects: [],
                     shield: 10,
                     power: 20,
                     rechargeRate: 5,
@@ -479,6 +594,8 @@ export const ExampleFS: RawDataStream = {
                         {
                             label: "api.dll",
                             sentinel: false,
+                            upkeep: false,
+                            effects: [],
                             shield: 10,
                             power: 20,
                             rechargeRate: 5,
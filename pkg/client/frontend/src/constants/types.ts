@@ -28,3 +28,22 @@ export const AttackType = {
 }
 
 export type AttackType = (typeof AttackType)[keyof typeof AttackType]
+
+// Mirrors the StatusEffectType enum of the game protocol.
+export const StatusEffectType = {
+    INFESTATION: 0,
+    DATA_BLEED: 1,
+    MASKED: 2,
+    BACKDOOR_ACCESS: 3,
+    POWER_SIPHON: 4,
+} as const satisfies {
+    [K in keyof typeof CONTENT.STATUS_EFFECTS]: number
+}
+
+export type StatusEffectType = (typeof StatusEffectType)[keyof typeof StatusEffectType]
+
+export type StatusEffect = {
+    type: StatusEffectType
+    remaining: number
+    stacks: number
+}
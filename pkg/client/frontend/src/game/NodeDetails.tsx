@@ -2,8 +2,17 @@ import { For, Show, VoidComponent } from "solid-js"
 import Description from "@components/Description"
 import InfoButton from "@components/InfoButton"
 import { CONTENT } from "@constants/content"
+import { StatusEffectType } from "@constants/types"
 import { SaplingMetadata } from "@game/tree"
 
+const effectContent = (type: StatusEffectType) => {
+    const name = (Object.keys(StatusEffectType) as (keyof typeof StatusEffectType)[]).find(
+        (key) => StatusEffectType[key] === type,
+    )!
+
+    return CONTENT.STATUS_EFFECTS[name]
+}
+
 type NodeDetailsPanelProps = {
     data: SaplingMetadata
 }
@@ -45,6 +54,29 @@ const NodeDetailsPanel: VoidComponent<NodeDetailsPanelProps> = (props) => {
                     )}
                 </For>
             </section>
+            <Show when={props.data.effects.length}>
+                <section class="my-2 flex flex-col gap-2">
+                    <For each={props.data.effects}>
+                        {(effect) => (
+                            <div class="flex w-full flex-row items-center p-4">
+                                <InfoButton
+                                    class="cursor-default px-6 py-3 tracking-normal"
+                                    embellish={false}
+                                    hintTitle={effectContent(effect.type).title}
+                                    hintBody={<p class="mt-2">{effectContent(effect.type).description}</p>}
+                                    hintClass="w-96"
+                                >
+                                    {effectContent(effect.type).icon}
+                                </InfoButton>
+                                <span class="ms-8 text-dark-turquoise">
+                                    {effectContent(effect.type).title} ×{effect.stacks}
+                                    <Show when={effect.remaining > 0}> ({effect.remaining} turns left)</Show>
+                                </span>
+                            </div>
+                        )}
+                    </For>
+                </section>
+            </Show>
             {/* TODO: Move this inside section */}
             <Show when={props.data.sentinel}>
                 <InfoButton
@@ -57,6 +89,17 @@ const NodeDetailsPanel: VoidComponent<NodeDetailsPanelProps> = (props) => {
                     {CONTENT.NODE_ATTRIBUTES.SENTINEL.icon}
                 </InfoButton>
             </Show>
+            <Show when={props.data.upkeep}>
+                <InfoButton
+                    class="ms-auto cursor-default px-4 py-3 tracking-normal"
+                    embellish={false}
+                    hintTitle={CONTENT.NODE_ATTRIBUTES.UPKEEP.title}
+                    hintBody={<Description content={CONTENT.NODE_ATTRIBUTES.UPKEEP} />}
+                    hintClass="w-96"
+                >
+                    {CONTENT.NODE_ATTRIBUTES.UPKEEP.icon}
+                </InfoButton>
+            </Show>
         </article>
     )
 }
@@ -10,6 +10,7 @@ import {
     Vector3,
     Vector3Tuple,
 } from "three"
+import { StatusEffect } from "@constants/types"
 
 // TODO: Fixup according to client structure.
 export type RawDataStream = SaplingMetadata & {
@@ -20,6 +21,7 @@ const NODE_RADIUS = 0.1
 const BASE_APOTHEM_RADIUS = 8
 const DEPTH_OFFSET = 4
 const COLORS = [0x7b68ee, 0xda1d81, 0xcccccc, 0x193751] as const
+const UPKEEP_EMISSIVE = 0x00d6fc
 
 // TODO: Build the positions bottom-up instead, so that nodes and connectors don't overlap.
 
@@ -57,9 +59,11 @@ type AddChildSaplingOptions = Required<Pick<SaplingCtorOptions, "depth" | "color
 export type SaplingMetadata = {
     label: string
     sentinel: boolean
+    upkeep: boolean
     power: number
     shield: number
     rechargeRate: number
+    effects: StatusEffect[]
 }
 
 type SaplingInternalData = {
@@ -129,9 +133,16 @@ export class Sapling extends Mesh {
         this.userData.shield = root.shield
         this.userData.rechargeRate = root.rechargeRate
         this.userData.sentinel = root.sentinel
+        this.userData.upkeep = root.upkeep
+        this.userData.effects = root.effects
         this.userData.depth = depth
         this.userData.accessPath = accessPath
 
+        // Upkeep nodes glow, as they share their recharge with the nodes around them.
+        if (root.upkeep) {
+            ;(this.material as MeshLambertMaterial).emissive.setHex(UPKEEP_EMISSIVE)
+        }
+
         if (collector[depth]) {
             collector[depth].push(this)
         } else {
@@ -0,0 +1,43 @@
+import { createSignal, onCleanup, onMount } from "solid-js"
+import { main } from "@wails/go/models"
+import { EventsOff, EventsOn } from "@wails/runtime/runtime"
+
+// TODO: Bind the game log and game event types from the go app instead.
+
+export type ReplayHeader = {
+    id?: string
+    seed?: number
+    players?: string[]
+}
+
+export type ReplayFrame = {
+    Event?: Record<string, unknown>
+}
+
+const useReplay = () => {
+    const [header, setHeader] = createSignal<ReplayHeader | undefined>(undefined)
+    const [frames, setFrames] = createSignal<ReplayFrame[]>([])
+    const [playing, setPlaying] = createSignal(false)
+
+    onMount(() => {
+        EventsOn(main.Event.REPLAY_HEADER, (replayHeader: ReplayHeader) => {
+            setHeader(replayHeader)
+            setFrames([])
+            setPlaying(true)
+        })
+        EventsOn(main.Event.REPLAY_FRAME, (frame: ReplayFrame) => {
+            setFrames((played) => [...played, frame])
+        })
+        EventsOn(main.Event.REPLAY_END, () => {
+            setPlaying(false)
+        })
+    })
+
+    onCleanup(() => {
+        EventsOff(main.Event.REPLAY_HEADER, main.Event.REPLAY_FRAME, main.Event.REPLAY_END)
+    })
+
+    return { header, frames, playing }
+}
+
+export default useReplay
@@ -1,3 +1,4 @@
+import { useNavigate } from "@solidjs/router"
 import { VoidComponent } from "solid-js"
 import NavButton from "@components/NavButton"
 import NukeSignal from "@components/NukeSignal"
@@ -7,9 +8,14 @@ import { PlacementPosition } from "@constants/types"
 import { Quit } from "@wails/runtime/runtime"
 
 const Landing: VoidComponent = () => {
+    const navigate = useNavigate()
+
     return (
         <>
             <div class="absolute inset-0 -z-10 bg-city bg-cover bg-center bg-no-repeat opacity-50 blur-sm brightness-50 -hue-rotate-15" />
+            <NavButton position={PlacementPosition.LEFT} onClick={() => navigate("/replay")}>
+                🎞️Replays
+            </NavButton>
             <NavButton position={PlacementPosition.RIGHT} onClick={Quit}>
                 🚪Quit
             </NavButton>
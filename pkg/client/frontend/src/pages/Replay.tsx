@@ -0,0 +1,135 @@
+import { useNavigate } from "@solidjs/router"
+import { For, Show, VoidComponent, createSignal } from "solid-js"
+import toast from "solid-toast"
+import Button from "@components/Button"
+import NavButton from "@components/NavButton"
+import { PlacementPosition } from "@constants/types"
+import useReplay, { ReplayFrame } from "@hooks/useReplay"
+import { LoadReplay, OpenReplay, SaveReplay, SetReplaySpeed, StopReplay } from "@wails/go/main/WailsApp"
+
+const REPLAY_SPEEDS = [0.5, 1, 2, 4] as const
+
+const describeFrame = (frame: ReplayFrame) => {
+    const [kind, payload] = Object.entries(frame.Event ?? {})[0] ?? ["Unknown", {}]
+
+    return { kind, details: JSON.stringify(payload) }
+}
+
+const Replay: VoidComponent = () => {
+    const navigate = useNavigate()
+    const { header, frames, playing } = useReplay()
+
+    const [gameID, setGameID] = createSignal("")
+    const [perspective, setPerspective] = createSignal("")
+    const [speed, setSpeed] = createSignal<number>(1)
+
+    const changeSpeed = (value: number) => {
+        setSpeed(value)
+        SetReplaySpeed(value)
+    }
+
+    const loadReplay = async () => {
+        if (!gameID()) {
+            toast.error("Enter the id of a finished game to replay.")
+            return
+        }
+
+        const loaded = await LoadReplay(gameID(), perspective())
+        if (!loaded) {
+            toast.error(`Cannot load the replay of game ${gameID()}.`)
+        }
+    }
+
+    const saveReplay = async () => {
+        if (!gameID()) {
+            toast.error("Enter the id of a finished game to save.")
+            return
+        }
+
+        try {
+            if (await SaveReplay(gameID())) {
+                toast.success(`Saved the replay of game ${gameID()}.`)
+            }
+        } catch {
+            toast.error(`Cannot save the replay of game ${gameID()}.`)
+        }
+    }
+
+    const openReplay = async () => {
+        try {
+            await OpenReplay()
+        } catch {
+            toast.error("Cannot open the replay file.")
+        }
+    }
+
+    const goBack = () => {
+        StopReplay()
+        navigate("/")
+    }
+
+    return (
+        <>
+            <NavButton position={PlacementPosition.LEFT} onClick={goBack}>
+                ⬅️Back
+            </NavButton>
+            <section class="flex w-2/3 flex-col gap-8 rounded-lg border border-dark-turquoise p-16 backdrop-blur-md">
+                <section class="flex flex-row items-center justify-evenly gap-8">
+                    <input
+                        class="min-h-16 w-64 rounded-lg border border-dark-turquoise/30 bg-transparent text-center text-dark-turquoise outline-none focus-visible:border-medium-slate-blue"
+                        placeholder="Game id..."
+                        value={gameID()}
+                        onInput={(e) => setGameID(e.currentTarget.value)}
+                    />
+                    <input
+                        class="min-h-16 w-64 rounded-lg border border-dark-turquoise/30 bg-transparent text-center text-dark-turquoise outline-none focus-visible:border-medium-slate-blue"
+                        placeholder="Perspective (optional)..."
+                        value={perspective()}
+                        onInput={(e) => setPerspective(e.currentTarget.value)}
+                    />
+                    <Button class="min-h-16 min-w-40" onClick={loadReplay}>
+                        Load Game
+                    </Button>
+                    <Button class="min-h-16 min-w-40" onClick={saveReplay}>
+                        Save File
+                    </Button>
+                    <Button class="min-h-16 min-w-40" onClick={openReplay}>
+                        Open File
+                    </Button>
+                </section>
+                <section class="flex flex-row items-center justify-center gap-4">
+                    <For each={REPLAY_SPEEDS}>
+                        {(value) => (
+                            <Button class="min-w-20" embellish={speed() === value} onClick={() => changeSpeed(value)}>
+                                {value}×
+                            </Button>
+                        )}
+                    </For>
+                    <Button class="min-w-20" disabled={!playing()} onClick={() => StopReplay()}>
+                        ⏹ Stop
+                    </Button>
+                </section>
+                <Show when={header()}>
+                    <p class="text-base/relaxed font-medium uppercase tracking-wide text-dark-turquoise text-shadow">
+                        Game {header()!.id} · Seed {header()!.seed ?? 0} · {(header()!.players ?? []).join(" vs ")}
+                        {playing() ? " · Playing" : " · Finished"}
+                    </p>
+                </Show>
+                <ol class="flex max-h-96 flex-col gap-2 overflow-y-auto font-mono text-sm text-dark-turquoise">
+                    <For each={frames()}>
+                        {(frame, idx) => (
+                            <li>
+                                <span class="me-4 text-medium-slate-blue">
+                                    {idx() + 1}. {describeFrame(frame).kind}
+                                </span>
+                                <span class="text-gray-300">{describeFrame(frame).details}</span>
+                            </li>
+                        )}
+                    </For>
+                </ol>
+            </section>
+        </>
+    )
+}
+
+export default Replay
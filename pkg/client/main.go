@@ -47,6 +47,9 @@ var (
 	}{
 		{StateChangeEvent, "STATE_CHANGE"},
 		{ServerConnectionChangeEvent, "SERVER_CONNECTION_CHANGE"},
+		{ReplayHeaderEvent, "REPLAY_HEADER"},
+		{ReplayFrameEvent, "REPLAY_FRAME"},
+		{ReplayEndEvent, "REPLAY_END"},
 	}
 )
 
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ReplayHeaderEvent Event = "srv:replayHeader"
+	ReplayFrameEvent  Event = "srv:replayFrame"
+	ReplayEndEvent    Event = "srv:replayEnd"
+
+	replayFrameInterval = 500 * time.Millisecond
+	defaultReplaySpeed  = 1.0
+	replayFileMode      = 0o644
+)
+
+var (
+	errReplayUnavailable = errors.New("replay is unavailable")
+
+	//nolint:gochecknoglobals // Dialog filter is shared by the save and open dialogs.
+	replayFileFilters = []runtime.FileFilter{
+		{DisplayName: "NukeShip Replays", Pattern: "*" + game.ReplayFileExtension},
+	}
+)
+
+// LoadReplay streams the events of a finished game and plays them back as seen by the perspective player. An empty
+// perspective replays every event of the game.
+func (app *WailsApp) LoadReplay(gameID, perspective string) bool {
+	streamCtx, cancel := client.NewStreamContext(app.configCtx)
+	defer cancel()
+
+	streamClient, err := app.GameClient.ReplayGame(
+		streamCtx,
+		&pb.ReplayGameRequest{GameId: gameID, Perspective: perspective},
+	)
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not load replay of game %s: %v", gameID, err)
+		return false
+	}
+
+	header, events, err := receiveReplay(streamClient)
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not load replay of game %s: %v", gameID, err)
+		return false
+	}
+
+	app.startReplay(header, events)
+
+	return true
+}
+
+// SaveReplay exports a finished game from the server into a replay file chosen by the user.
+func (app *WailsApp) SaveReplay(gameID string) (bool, error) {
+	unaryCtx, cancel := client.NewUnaryContext(app.configCtx)
+	defer cancel()
+
+	resp, err := app.GameClient.ExportReplay(unaryCtx, &pb.ExportReplayRequest{GameId: gameID})
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Could not export replay: %v", err)
+
+		return false, fmt.Errorf("could not export replay: %w", err)
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		runtime.LogErrorf(app.wailsCtx, "Unable to export replay: %s", resp.GetStatus().String())
+
+		return false, nil
+	}
+
+	path, err := runtime.SaveFileDialog(app.wailsCtx, runtime.SaveDialogOptions{
+		DefaultDirectory:           "",
+		DefaultFilename:            gameID + game.ReplayFileExtension,
+		Title:                      "Save Replay",
+		Filters:                    replayFileFilters,
+		ShowHiddenFiles:            false,
+		CanCreateDirectories:       true,
+		TreatPackagesAsDirectories: false,
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not pick replay file: %w", err)
+	}
+
+	if path == "" {
+		return false, nil
+	}
+
+	err = os.WriteFile(path, resp.GetReplay(), replayFileMode)
+	if err != nil {
+		return false, fmt.Errorf("could not save replay: %w", err)
+	}
+
+	runtime.LogDebugf(app.wailsCtx, "Saved replay of game %s to %s", gameID, path)
+
+	return true, nil
+}
+
+// OpenReplay loads a replay file chosen by the user and plays it back without a server connection.
+func (app *WailsApp) OpenReplay() (bool, error) {
+	path, err := runtime.OpenFileDialog(app.wailsCtx, runtime.OpenDialogOptions{
+		DefaultDirectory:           "",
+		DefaultFilename:            "",
+		Title:                      "Open Replay",
+		Filters:                    replayFileFilters,
+		ShowHiddenFiles:            false,
+		CanCreateDirectories:       false,
+		ResolvesAliases:            true,
+		TreatPackagesAsDirectories: false,
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not pick replay file: %w", err)
+	}
+
+	if path == "" {
+		return false, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("could not open replay: %w", err)
+	}
+	defer file.Close()
+
+	replay, err := game.ReadReplay(file)
+	if err != nil {
+		return false, fmt.Errorf("could not read replay: %w", err)
+	}
+
+	events, err := game.ReplayEvents(replay.GetGame(), "")
+	if err != nil {
+		runtime.LogErrorf(app.wailsCtx, "Replay diverged from the current rules: %v", err)
+	}
+
+	header := proto.CloneOf(replay.GetGame())
+	header.Entries = nil
+
+	app.startReplay(header, events)
+
+	return true, nil
+}
+
+// SetReplaySpeed changes the playback speed of the replay. A speed of 2 plays events twice as fast.
+func (app *WailsApp) SetReplaySpeed(speed float64) {
+	if speed <= 0 {
+		return
+	}
+
+	app.replaySpeed.Store(math.Float64bits(speed))
+}
+
+func (app *WailsApp) StopReplay() {
+	if app.stopReplay != nil {
+		app.stopReplay()
+		app.stopReplay = nil
+	}
+}
+
+func receiveReplay(
+	streamClient pb.GameService_ReplayGameClient,
+) (*pb.GameLog, []*pb.GameEvent, error) {
+	var header *pb.GameLog
+
+	events := []*pb.GameEvent{}
+
+	for {
+		frame, err := streamClient.Recv()
+		if errors.Is(err, io.EOF) {
+			return header, events, nil
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("replay stream stopped: %w", err)
+		}
+
+		if frame.GetStatus() != pb.ResponseStatus_Ok {
+			return nil, nil, fmt.Errorf("%w: %s", errReplayUnavailable, frame.GetStatus().String())
+		}
+
+		if frame.GetHeader() != nil {
+			header = frame.GetHeader()
+			continue
+		}
+
+		events = append(events, frame.GetEvent())
+	}
+}
+
+func (app *WailsApp) startReplay(header *pb.GameLog, events []*pb.GameEvent) {
+	app.StopReplay()
+
+	ctx, cancel := context.WithCancel(app.configCtx)
+	app.stopReplay = cancel
+
+	runtime.EventsEmit(app.wailsCtx, string(ReplayHeaderEvent), header)
+
+	go app.playReplay(ctx, events)
+}
+
+func (app *WailsApp) playReplay(ctx context.Context, events []*pb.GameEvent) {
+	defer runtime.EventsEmit(app.wailsCtx, string(ReplayEndEvent))
+
+	for _, event := range events {
+		speed := math.Float64frombits(app.replaySpeed.Load())
+		delay := time.Duration(float64(replayFrameInterval) / speed)
+
+		select {
+		case <-time.After(delay):
+			runtime.EventsEmit(app.wailsCtx, string(ReplayFrameEvent), event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
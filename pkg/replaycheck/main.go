@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/passeriform/internal/game"
+)
+
+func verify(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open replay: %w", err)
+	}
+	defer file.Close()
+
+	header, err := game.ReadReplay(file)
+	if err != nil {
+		return fmt.Errorf("could not read replay: %w", err)
+	}
+
+	err = game.VerifyReplay(header)
+	if err != nil {
+		return fmt.Errorf("replay is inconsistent with the rules engine: %w", err)
+	}
+
+	return nil
+}
+
+// Verifies that replay files are consistent with the current rules engine.
+//
+// Usage: replaycheck <file.nukereplay>...
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatalf("Usage: %s <file%s>...", os.Args[0], game.ReplayFileExtension)
+	}
+
+	failed := false
+
+	for _, path := range os.Args[1:] {
+		err := verify(path)
+		if err != nil {
+			log.Printf("FAIL %s: %v", path, err)
+
+			failed = true
+
+			continue
+		}
+
+		log.Printf("OK   %s", path)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/passeriform/internal/pb"
+	"github.com/passeriform/internal/server"
+)
+
+type BotService struct {
+	pb.UnimplementedBotServiceServer `exhaustruct:"optional"`
+
+	//nolint:containedctx // Carrying shutdown context for in-request client cancellation.
+	ShutdownCtx context.Context
+}
+
+// Play forwards room state changes and game events to the bot as observations, and applies the trees, actions and
+// purchases sent by the bot. Every message of the bot is answered with an observation carrying its status.
+func (srv *BotService) Play(stream grpc.BidiStreamingServer[pb.BotMessage, pb.Observation]) error {
+	clientID, _ := server.ExtractClientIDMetadata(stream.Context())
+	conn := server.GetConnection(clientID)
+
+	replies := make(chan pb.ResponseStatus)
+
+	go receiveBotMessages(stream, conn, replies)
+
+	for {
+		var observation *pb.Observation
+
+		select {
+		case msg := <-conn.MsgChan:
+			observation = observe(conn, &pb.Observation_RoomState{RoomState: msg.GetType()})
+
+		case event := <-conn.GameChan:
+			observation = observe(conn, &pb.Observation_Event{Event: event})
+
+		case reply, ok := <-replies:
+			if !ok {
+				log.Printf("Bot %s stopped sending messages", conn.ID)
+				conn.Remove()
+
+				return nil
+			}
+
+			observation = observe(conn, &pb.Observation_Status{Status: reply})
+
+		case <-conn.Closed():
+			log.Printf("Bot %s no longer receives game events", conn.ID)
+			conn.Remove()
+
+			return status.Errorf(codes.ResourceExhausted, "Game events are no longer delivered")
+
+		case <-stream.Context().Done():
+			log.Printf("Bot %s was disconnected", conn.ID)
+			conn.Remove()
+
+			return nil
+
+		case <-srv.ShutdownCtx.Done():
+			log.Printf("Server shutting down. Disconnecting bot %s", clientID)
+			return status.Errorf(codes.Unavailable, "Server is shutting down")
+		}
+
+		err := stream.Send(observation)
+		if err != nil {
+			log.Printf("Error sending observation: %v", err)
+			return nil
+		}
+	}
+}
+
+func receiveBotMessages(
+	stream grpc.BidiStreamingServer[pb.BotMessage, pb.Observation],
+	conn *server.Connection,
+	replies chan<- pb.ResponseStatus,
+) {
+	defer close(replies)
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			log.Printf("Error receiving bot message: %v", err)
+			return
+		}
+
+		select {
+		case replies <- applyBotMessage(conn, msg):
+		case <-stream.Context().Done():
+			return
+		}
+	}
+}
+
+func applyBotMessage(conn *server.Connection, msg *pb.BotMessage) pb.ResponseStatus {
+	if conn.Room == nil {
+		return pb.ResponseStatus_NoRoomJoinedYet
+	}
+
+	current := conn.Room.Game
+	if current == nil {
+		return pb.ResponseStatus_NoGameStartedYet
+	}
+
+	switch payload := msg.GetPayload().(type) {
+	case *pb.BotMessage_Tree:
+		return gameStatus(current.AddPlayerState(conn.ID, payload.Tree))
+	case *pb.BotMessage_Action:
+		return gameStatus(current.Submit(conn.ID, payload.Action))
+	case *pb.BotMessage_Purchase:
+		return gameStatus(current.Purchase(conn.ID, payload.Purchase))
+	default:
+		return pb.ResponseStatus_InvalidAction
+	}
+}
+
+// observe builds an observation of the trigger along with the latest board of the bot, if a game is running.
+func observe(conn *server.Connection, trigger pb.ObservationTrigger) *pb.Observation {
+	observation := &pb.Observation{Trigger: trigger, Board: nil, YourTurn: false}
+
+	if conn.Room == nil || conn.Room.Game == nil {
+		return observation
+	}
+
+	current := conn.Room.Game
+
+	board, err := current.Board(conn.ID)
+	if err != nil {
+		return observation
+	}
+
+	observation.Board, observation.YourTurn = board, current.CanAct(conn.ID)
+
+	return observation
+}
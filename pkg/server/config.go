@@ -1,5 +1,8 @@
 package main
 
 type ServerConfig struct {
-	Port int
+	// CampaignPath points to the data file of the campaign missions. The campaign shipped with the game is served
+	// when it is empty.
+	CampaignPath string
+	Port         int
 }
@@ -4,5 +4,6 @@ package main
 
 //nolint:gochecknoglobals,mnd // Client config to be used only via build-time tag toggling.
 var Config = ServerConfig{
-	Port: 80,
+	CampaignPath: "",
+	Port:         80,
 }
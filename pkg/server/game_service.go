@@ -1,10 +1,20 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/passeriform/internal/game"
 	"github.com/passeriform/internal/pb"
 	"github.com/passeriform/internal/server"
+	"google.golang.org/protobuf/proto"
 )
 
 type GameService struct {
@@ -28,7 +38,205 @@ func (*GameService) AddPlayer(
 
 	room := conn.Room
 
-	room.Game.AddPlayerState(clientID, tree)
+	if room.Game == nil {
+		return &pb.AddPlayerResponse{Status: pb.ResponseStatus_NoGameStartedYet}, nil
+	}
+
+	// Missions are played on their fixed trees regardless of the tree the client publishes.
+	if missionTree := room.MissionTree(clientID); missionTree != nil {
+		tree = missionTree
+	}
+
+	err := room.Game.AddPlayerState(clientID, tree)
+
+	return &pb.AddPlayerResponse{Status: gameStatus(err)}, nil
+}
+
+func (*GameService) GetBoard(
+	ctx context.Context,
+	_ *pb.GetBoardRequest,
+) (*pb.GetBoardResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+	conn := server.GetConnection(clientID)
+
+	if conn.Room == nil {
+		return &pb.GetBoardResponse{Status: pb.ResponseStatus_NoRoomJoinedYet, Board: nil}, nil
+	}
+
+	if conn.Room.Game == nil {
+		return &pb.GetBoardResponse{Status: pb.ResponseStatus_NoGameStartedYet, Board: nil}, nil
+	}
+
+	board, err := conn.Room.Game.Board(clientID)
+
+	return &pb.GetBoardResponse{Status: gameStatus(err), Board: board}, nil
+}
+
+func (*GameService) SubmitAction(
+	ctx context.Context,
+	in *pb.SubmitActionRequest,
+) (*pb.SubmitActionResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+	conn := server.GetConnection(clientID)
+
+	if conn.Room == nil {
+		return &pb.SubmitActionResponse{Status: pb.ResponseStatus_NoRoomJoinedYet}, nil
+	}
+
+	if conn.Room.Game == nil {
+		return &pb.SubmitActionResponse{Status: pb.ResponseStatus_NoGameStartedYet}, nil
+	}
+
+	err := conn.Room.Game.Submit(clientID, in.GetAction())
+	if err != nil {
+		log.Printf("Rejected action from client %s: %v", clientID, err)
+	}
+
+	return &pb.SubmitActionResponse{Status: gameStatus(err)}, nil
+}
+
+func (*GameService) Purchase(
+	ctx context.Context,
+	in *pb.PurchaseRequest,
+) (*pb.PurchaseResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+	conn := server.GetConnection(clientID)
+
+	if conn.Room == nil {
+		return &pb.PurchaseResponse{Status: pb.ResponseStatus_NoRoomJoinedYet}, nil
+	}
 
-	return &pb.AddPlayerResponse{Status: pb.ResponseStatus_Ok}, nil
+	if conn.Room.Game == nil {
+		return &pb.PurchaseResponse{Status: pb.ResponseStatus_NoGameStartedYet}, nil
+	}
+
+	err := conn.Room.Game.Purchase(clientID, in.GetPurchase())
+	if err != nil {
+		log.Printf("Rejected purchase from client %s: %v", clientID, err)
+	}
+
+	return &pb.PurchaseResponse{Status: gameStatus(err)}, nil
+}
+
+func (srv *GameService) SubscribeGameEvents(
+	_ *pb.SubscribeGameEventsRequest,
+	stream grpc.ServerStreamingServer[pb.GameEvent],
+) error {
+	clientID, _ := server.ExtractClientIDMetadata(stream.Context())
+	conn := server.GetConnection(clientID)
+
+	for {
+		select {
+		case event := <-conn.GameChan:
+			err := stream.Send(event)
+			if err != nil {
+				log.Printf("Error sending game event: %v", err)
+				return nil
+			}
+
+		case <-conn.Closed():
+			log.Printf("Client %s no longer receives game events", conn.ID)
+			return status.Errorf(codes.ResourceExhausted, "Game events are no longer delivered")
+
+		case <-stream.Context().Done():
+			log.Printf("Client %s unsubscribed from game events", conn.ID)
+			return nil
+
+		case <-srv.ShutdownCtx.Done():
+			log.Printf("Server shutting down. Closing game events for client %s", clientID)
+			return status.Errorf(codes.Unavailable, "Server is shutting down")
+		}
+	}
+}
+
+func (*GameService) ReplayGame(
+	in *pb.ReplayGameRequest,
+	stream grpc.ServerStreamingServer[pb.ReplayGameResponse],
+) error {
+	archived := server.GetArchivedGame(in.GetGameId())
+
+	// Live games are not replayed to avoid leaking the hidden state of players.
+	if archived == nil || !archived.Over() {
+		err := stream.Send(
+			&pb.ReplayGameResponse{Status: pb.ResponseStatus_GameNotFound, Frame: nil},
+		)
+		if err != nil {
+			return fmt.Errorf("could not send replay status: %w", err)
+		}
+
+		return nil
+	}
+
+	gameLog := archived.Log()
+
+	events, err := game.ReplayEvents(gameLog, in.GetPerspective())
+	if err != nil {
+		log.Printf("Replay of game %s diverged from its log: %v", gameLog.GetId(), err)
+
+		return status.Errorf(codes.DataLoss, "replay of game %s diverged", gameLog.GetId())
+	}
+
+	header := proto.CloneOf(gameLog)
+	header.Entries = nil
+
+	err = stream.Send(&pb.ReplayGameResponse{
+		Status: pb.ResponseStatus_Ok,
+		Frame:  &pb.ReplayGameResponse_Header{Header: header},
+	})
+	if err != nil {
+		return fmt.Errorf("could not send replay header: %w", err)
+	}
+
+	for _, event := range events {
+		err := stream.Send(&pb.ReplayGameResponse{
+			Status: pb.ResponseStatus_Ok,
+			Frame:  &pb.ReplayGameResponse_Event{Event: event},
+		})
+		if err != nil {
+			return fmt.Errorf("could not send replay event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (*GameService) ExportReplay(
+	_ context.Context,
+	in *pb.ExportReplayRequest,
+) (*pb.ExportReplayResponse, error) {
+	archived := server.GetArchivedGame(in.GetGameId())
+
+	if archived == nil || !archived.Over() {
+		return &pb.ExportReplayResponse{Status: pb.ResponseStatus_GameNotFound, Replay: nil}, nil
+	}
+
+	var replay bytes.Buffer
+
+	err := game.WriteReplay(&replay, game.NewReplayHeader(archived))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not export replay: %v", err)
+	}
+
+	return &pb.ExportReplayResponse{Status: pb.ResponseStatus_Ok, Replay: replay.Bytes()}, nil
+}
+
+func gameStatus(err error) pb.ResponseStatus {
+	switch {
+	case err == nil:
+		return pb.ResponseStatus_Ok
+	case errors.Is(err, game.ErrGameNotStarted):
+		return pb.ResponseStatus_NoGameStartedYet
+	case errors.Is(err, game.ErrGameOver), errors.Is(err, game.ErrPlayerEliminated):
+		return pb.ResponseStatus_GameAlreadyOver
+	case errors.Is(err, game.ErrNotYourTurn):
+		return pb.ResponseStatus_NotYourTurn
+	case errors.Is(err, game.ErrInsufficientPower):
+		return pb.ResponseStatus_InsufficientPower
+	case errors.Is(err, game.ErrAbilityOnCooldown):
+		return pb.ResponseStatus_AbilityOnCooldown
+	case errors.Is(err, game.ErrAbilityLocked):
+		return pb.ResponseStatus_AbilityLocked
+	default:
+		return pb.ResponseStatus_InvalidAction
+	}
 }
@@ -43,6 +43,11 @@ func handleQuit(cancel context.CancelFunc, srv *grpc.Server) {
 }
 
 func main() {
+	err := server.LoadCampaign(Config.CampaignPath)
+	if err != nil {
+		log.Panicf("Failed to load campaign: %v", err)
+	}
+
 	lc := net.ListenConfig{KeepAlive: KeepAliveServerParameters.Time}
 
 	lis, err := lc.Listen(context.Background(), "tcp", ":"+strconv.Itoa(Config.Port))
@@ -75,6 +80,7 @@ func main() {
 		&RoomService{ShutdownCtx: shutdownCtx},
 	)
 	pb.RegisterGameServiceServer(srv, &GameService{ShutdownCtx: shutdownCtx})
+	pb.RegisterBotServiceServer(srv, &BotService{ShutdownCtx: shutdownCtx})
 
 	if err := srv.Serve(lis); err != nil {
 		log.Panicf("Failed to serve: %v", err)
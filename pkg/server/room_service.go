@@ -25,11 +25,7 @@ func (*RoomService) CreateRoom(
 ) (*pb.CreateRoomResponse, error) {
 	clientID, _ := server.ExtractClientIDMetadata(ctx)
 	conn := server.GetConnection(clientID)
-	room, _ := server.NewRoom(in.GetRoomType(), func(room *server.Room, state pb.RoomState) {
-		for _, partConn := range room.Clients {
-			partConn.MsgChan <- &pb.MessageStreamResponse{Type: state}
-		}
-	})
+	room, _ := server.NewRoom(in.GetRoomType(), in.GetGameMode(), broadcastRoomState)
 
 	//nolint:contextcheck // Intentionally decoupled from request context
 	room.AddConnection(conn)
@@ -41,6 +37,46 @@ func (*RoomService) CreateRoom(
 	return &pb.CreateRoomResponse{Status: pb.ResponseStatus_Ok, RoomId: room.ID}, nil
 }
 
+// ListMissions lists the missions of the campaign in the order they are meant to be played in.
+func (*RoomService) ListMissions(
+	_ context.Context,
+	_ *pb.ListMissionsRequest,
+) (*pb.ListMissionsResponse, error) {
+	return &pb.ListMissionsResponse{Status: pb.ResponseStatus_Ok, Campaign: server.Campaign()}, nil
+}
+
+// StartMission creates a room for the mission and fills it with the bot opponent of the mission. The game starts
+// once the client is ready.
+func (*RoomService) StartMission(
+	ctx context.Context,
+	in *pb.StartMissionRequest,
+) (*pb.StartMissionResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+	conn := server.GetConnection(clientID)
+
+	mission := server.GetMission(in.GetMissionId())
+	if mission == nil {
+		return &pb.StartMissionResponse{Status: pb.ResponseStatus_MissionNotFound, RoomId: ""}, nil
+	}
+
+	room, _ := server.NewRoom(pb.RoomType_Regular, mission.GetGameMode(), broadcastRoomState)
+	room.Mission = mission
+
+	//nolint:contextcheck // Intentionally decoupled from request context
+	room.AddConnection(conn)
+
+	conn.Room = room
+
+	room.SetFaction(conn.ID, mission.GetFaction())
+
+	//nolint:contextcheck // Intentionally decoupled from request context
+	room.FillWithBots(mission.GetOpponent(), mission.GetAdaptationRate())
+
+	log.Printf("Started mission %s in room %v", mission.GetId(), room.ID)
+
+	return &pb.StartMissionResponse{Status: pb.ResponseStatus_Ok, RoomId: room.ID}, nil
+}
+
 func (*RoomService) JoinRoom(
 	ctx context.Context,
 	in *pb.JoinRoomRequest,
@@ -109,6 +145,50 @@ func (*RoomService) UpdateReady(
 	return &pb.UpdateReadyResponse{Status: pb.ResponseStatus_Ok}, nil
 }
 
+func (*RoomService) SelectFaction(
+	ctx context.Context,
+	in *pb.SelectFactionRequest,
+) (*pb.SelectFactionResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+	faction := in.GetFaction()
+
+	conn := server.GetConnection(clientID)
+
+	if conn.Room == nil {
+		return &pb.SelectFactionResponse{Status: pb.ResponseStatus_NoRoomJoinedYet}, nil
+	}
+
+	if _, ok := pb.Faction_name[int32(faction)]; !ok {
+		return &pb.SelectFactionResponse{Status: pb.ResponseStatus_InvalidAction}, nil
+	}
+
+	conn.Room.SetFaction(conn.ID, faction)
+
+	log.Printf("Client selected faction: %s", faction.String())
+
+	return &pb.SelectFactionResponse{Status: pb.ResponseStatus_Ok}, nil
+}
+
+func (*RoomService) FillWithBots(
+	ctx context.Context,
+	in *pb.FillWithBotsRequest,
+) (*pb.FillWithBotsResponse, error) {
+	clientID, _ := server.ExtractClientIDMetadata(ctx)
+
+	conn := server.GetConnection(clientID)
+
+	if conn.Room == nil {
+		return &pb.FillWithBotsResponse{Status: pb.ResponseStatus_NoRoomJoinedYet, BotIds: nil}, nil
+	}
+
+	//nolint:contextcheck // Intentionally decoupled from request context
+	botIDs := conn.Room.FillWithBots(in.GetDifficulty(), in.GetAdaptationRate())
+
+	log.Printf("Filled room %s with %d bots", conn.Room.ID, len(botIDs))
+
+	return &pb.FillWithBotsResponse{Status: pb.ResponseStatus_Ok, BotIds: botIDs}, nil
+}
+
 func (srv *RoomService) SubscribeMessages(
 	_ *pb.SubscribeMessagesRequest,
 	stream grpc.ServerStreamingServer[pb.MessageStreamResponse],
@@ -137,3 +217,9 @@ func (srv *RoomService) SubscribeMessages(
 		}
 	}
 }
+
+func broadcastRoomState(room *server.Room, state pb.RoomState) {
+	for _, partConn := range room.Connections() {
+		partConn.MsgChan <- &pb.MessageStreamResponse{Type: state}
+	}
+}
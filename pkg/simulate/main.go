@@ -0,0 +1,246 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	defaultGames           = 1000
+	treeGenDepth           = 3
+	treeGenWidth           = 4
+	treeGenVisibilityDepth = 2
+	formatCSV              = "csv"
+	formatJSON             = "json"
+)
+
+var errInvalidFlag = errors.New("invalid flag")
+
+type simulateOptions struct {
+	bots      string
+	mode      string
+	format    string
+	abilities string
+	costs     string
+	game.SimulationOptions
+}
+
+func parseFlags() simulateOptions {
+	opts := simulateOptions{
+		bots:      "",
+		mode:      "",
+		format:    "",
+		abilities: "",
+		costs:     "",
+		SimulationOptions: game.SimulationOptions{
+			Costs:     map[pb.ActionType]int32{},
+			Abilities: []pb.ActionType{},
+			TreeOpts: game.TreeGenOptions{
+				Ignore:          nil,
+				VisibilityDepth: 0,
+				Depth:           0,
+				Width:           0,
+			},
+			Difficulties:   [2]pb.BotDifficulty{},
+			Seed:           0,
+			Games:          0,
+			AdaptationRate: 0,
+			Mode:           pb.GameMode_Turns,
+			MaxTurns:       0,
+			Power:          0,
+			Shield:         0,
+			RechargeRate:   0,
+		},
+	}
+
+	var power, shield, rechargeRate, maxTurns int
+
+	flag.IntVar(&opts.Games, "games", defaultGames, "Number of games to simulate")
+	flag.Uint64Var(&opts.Seed, "seed", 1, "Seed the games are derived from")
+	flag.StringVar(&opts.bots, "bots", "Hard,Hard", "Difficulties of the first and second player")
+	flag.Float64Var(
+		&opts.AdaptationRate,
+		"adaptation",
+		0,
+		"Adaptation rate of Adaptive bots, or the default when 0",
+	)
+	flag.StringVar(&opts.mode, "mode", pb.GameMode_Turns.String(), "Game mode of the games")
+	flag.IntVar(
+		&maxTurns,
+		"max-turns",
+		game.DefaultSimulationMaxTurns,
+		"Turns after which a game is abandoned",
+	)
+	flag.IntVar(&opts.TreeOpts.Depth, "depth", treeGenDepth, "Maximum depth of the generated trees")
+	flag.IntVar(&opts.TreeOpts.Width, "width", treeGenWidth, "Maximum children per tree node")
+	flag.IntVar(
+		&opts.TreeOpts.VisibilityDepth,
+		"visibility-depth",
+		treeGenVisibilityDepth,
+		"Depth up to which nodes start visible",
+	)
+	flag.IntVar(&power, "power", 0, "Power of every node, or the default when 0")
+	flag.IntVar(&shield, "shield", 0, "Shield of every node, or the default when 0")
+	flag.IntVar(&rechargeRate, "recharge", 0, "Recharge rate of every node, or the default when 0")
+	flag.StringVar(
+		&opts.abilities,
+		"abilities",
+		"",
+		"Abilities the bots use on their targets before striking them, such as Nuke,Infest",
+	)
+	flag.StringVar(
+		&opts.costs,
+		"cost",
+		"",
+		"Ability costs replacing the defaults, such as Strike=8,Nuke=40",
+	)
+	flag.StringVar(&opts.format, "format", formatCSV, "Report format, one of: csv, json")
+	flag.Parse()
+
+	//nolint:gosec // Ratings and turn limits given on the command line are small.
+	opts.Power, opts.Shield, opts.RechargeRate, opts.MaxTurns = int32(power), int32(shield),
+		int32(rechargeRate), int32(maxTurns)
+
+	return opts
+}
+
+func run(opts simulateOptions) error {
+	err := validate(opts)
+	if err != nil {
+		return err
+	}
+
+	bots := strings.Split(opts.bots, ",")
+	if len(bots) != len(opts.Difficulties) {
+		return fmt.Errorf("%w: expected %d bots", errInvalidFlag, len(opts.Difficulties))
+	}
+
+	for idx, name := range bots {
+		difficulty, ok := pb.BotDifficulty_value[strings.TrimSpace(name)]
+		if !ok {
+			return fmt.Errorf("%w: unknown bot difficulty %s", errInvalidFlag, name)
+		}
+
+		opts.Difficulties[idx] = pb.BotDifficulty(difficulty)
+	}
+
+	mode, ok := pb.GameMode_value[opts.mode]
+	if !ok {
+		return fmt.Errorf("%w: unknown game mode %s", errInvalidFlag, opts.mode)
+	}
+
+	opts.Mode = pb.GameMode(mode)
+
+	opts.Abilities, err = parseAbilities(opts.abilities)
+	if err != nil {
+		return err
+	}
+
+	opts.Costs, err = parseCosts(opts.costs)
+	if err != nil {
+		return err
+	}
+
+	report := game.Simulate(opts.SimulationOptions)
+
+	switch opts.format {
+	case formatCSV:
+		return report.WriteCSV(os.Stdout)
+	case formatJSON:
+		return report.WriteJSON(os.Stdout)
+	default:
+		return fmt.Errorf("%w: unknown format %s", errInvalidFlag, opts.format)
+	}
+}
+
+// validate rejects game counts, tree shapes, turn limits and ratings the simulation cannot play with.
+func validate(opts simulateOptions) error {
+	switch {
+	case opts.Games <= 0:
+		return fmt.Errorf("%w: games must be positive", errInvalidFlag)
+	case opts.TreeOpts.Depth <= 0, opts.TreeOpts.Width <= 0:
+		return fmt.Errorf("%w: depth and width must be positive", errInvalidFlag)
+	case opts.TreeOpts.VisibilityDepth < 0:
+		return fmt.Errorf("%w: visibility depth must not be negative", errInvalidFlag)
+	case opts.MaxTurns < 0, opts.Power < 0, opts.Shield < 0, opts.RechargeRate < 0:
+		return fmt.Errorf("%w: turn limit and ratings must not be negative", errInvalidFlag)
+	default:
+		return nil
+	}
+}
+
+// parseAbilities parses a comma-separated list of ability names.
+func parseAbilities(list string) ([]pb.ActionType, error) {
+	abilities := []pb.ActionType{}
+
+	for name := range strings.SplitSeq(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		ability, err := parseAbility(name)
+		if err != nil {
+			return nil, err
+		}
+
+		abilities = append(abilities, ability)
+	}
+
+	return abilities, nil
+}
+
+// parseCosts parses a comma-separated list of ability costs written as Name=Cost.
+func parseCosts(list string) (map[pb.ActionType]int32, error) {
+	costs := map[pb.ActionType]int32{}
+
+	for pair := range strings.SplitSeq(list, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: expected Name=Cost, got %s", errInvalidFlag, pair)
+		}
+
+		ability, err := parseAbility(name)
+		if err != nil {
+			return nil, err
+		}
+
+		cost, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || cost < 0 {
+			return nil, fmt.Errorf("%w: invalid cost %s of %s", errInvalidFlag, value, name)
+		}
+
+		costs[ability] = int32(cost) //nolint:gosec // Parsed as a 32-bit integer.
+	}
+
+	return costs, nil
+}
+
+func parseAbility(name string) (pb.ActionType, error) {
+	ability, ok := pb.ActionType_value[strings.TrimSpace(name)]
+	if !ok || pb.ActionType(ability) == pb.ActionType_EndTurn {
+		return pb.ActionType_EndTurn, fmt.Errorf("%w: unknown ability %s", errInvalidFlag, name)
+	}
+
+	return pb.ActionType(ability), nil
+}
+
+func main() {
+	err := run(parseFlags())
+	if err != nil {
+		log.Fatalf("Simulation failed: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/grpc"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+	"github.com/passeriform/pkg/botsdk"
+)
+
+const (
+	defaultAddress = "localhost:50051"
+	defaultRounds  = 5
+	formatText     = "text"
+	formatJSON     = "json"
+)
+
+var errInvalidFlag = errors.New("invalid flag")
+
+type (
+	tournamentOptions struct {
+		address string
+		bots    string
+		mode    string
+		format  string
+		rounds  int
+		tls     bool
+	}
+
+	entrant struct {
+		Name       string  `json:"name"`
+		Played     int     `json:"played"`
+		Wins       int     `json:"wins"`
+		Losses     int     `json:"losses"`
+		Draws      int     `json:"draws"`
+		WinRate    float64 `json:"winRate"`
+		difficulty pb.BotDifficulty
+	}
+
+	matchResult struct {
+		result *botsdk.Result
+		err    error
+	}
+)
+
+// playMatch pits two bots against each other in a fresh room. The first bot creates the room and moves first.
+func playMatch(
+	conn *grpc.ClientConn,
+	mode pb.GameMode,
+	first, second *entrant,
+) (*botsdk.Result, error) {
+	host, err := botsdk.Connect(conn, "")
+	if err != nil {
+		return nil, err
+	}
+	defer host.Close()
+
+	guest, err := botsdk.Connect(conn, "")
+	if err != nil {
+		return nil, err
+	}
+	defer guest.Close()
+
+	guest.SeatAfter(host)
+
+	bots := []*botsdk.Client{host, guest}
+
+	roomID, err := host.CreateRoom(pb.RoomType_Regular, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	err = guest.JoinRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan matchResult, len(bots))
+
+	for idx, player := range []*entrant{first, second} {
+		go func() {
+			agent := botsdk.NewStrategyAgent(player.difficulty, game.NewSeed())
+			result, err := bots[idx].Play(agent)
+			results <- matchResult{result: result, err: err}
+		}()
+	}
+
+	outcome := <-results
+	if outcome.err != nil {
+		return nil, outcome.err
+	}
+
+	// Map the winning client back to the entrant it played for.
+	switch outcome.result.Winner {
+	case host.ID:
+		outcome.result.Winner = first.Name
+	case guest.ID:
+		outcome.result.Winner = second.Name
+	}
+
+	return outcome.result, nil
+}
+
+func (e *entrant) record(winner string) {
+	e.Played++
+
+	switch winner {
+	case "":
+		e.Draws++
+	case e.Name:
+		e.Wins++
+	default:
+		e.Losses++
+	}
+
+	e.WinRate = float64(e.Wins) / float64(e.Played)
+}
+
+func parseEntrants(bots string) ([]*entrant, error) {
+	entrants := []*entrant{}
+
+	for idx, name := range strings.Split(bots, ",") {
+		difficulty, ok := pb.BotDifficulty_value[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown bot difficulty %s", errInvalidFlag, name)
+		}
+
+		entrants = append(entrants, &entrant{
+			Name:       fmt.Sprintf("%d:%s", idx+1, strings.TrimSpace(name)),
+			Played:     0,
+			Wins:       0,
+			Losses:     0,
+			Draws:      0,
+			WinRate:    0,
+			difficulty: pb.BotDifficulty(difficulty),
+		})
+	}
+
+	return entrants, nil
+}
+
+// run plays every pairing of the entrants for the given rounds, alternating which bot moves first.
+func run(opts tournamentOptions) ([]*entrant, error) {
+	entrants, err := parseEntrants(opts.bots)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, ok := pb.GameMode_value[opts.mode]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown game mode %s", errInvalidFlag, opts.mode)
+	}
+
+	conn, err := client.Dial(opts.address, opts.tls)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	for round := range opts.rounds {
+		for i := range entrants {
+			for j := i + 1; j < len(entrants); j++ {
+				first, second := entrants[i], entrants[j]
+				if round%2 == 1 {
+					first, second = second, first
+				}
+
+				result, err := playMatch(conn, pb.GameMode(mode), first, second)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"match of %s and %s failed: %w",
+						first.Name,
+						second.Name,
+						err,
+					)
+				}
+
+				log.Printf(
+					"Round %d: %s vs %s, winner %q after %d turns in game %s",
+					round+1,
+					first.Name,
+					second.Name,
+					result.Winner,
+					result.Turns,
+					result.GameID,
+				)
+
+				first.record(result.Winner)
+				second.record(result.Winner)
+			}
+		}
+	}
+
+	return entrants, nil
+}
+
+func report(entrants []*entrant, format string) error {
+	if format == formatJSON {
+		err := json.NewEncoder(os.Stdout).Encode(entrants)
+		if err != nil {
+			return fmt.Errorf("could not encode report: %w", err)
+		}
+
+		return nil
+	}
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd // Column padding.
+
+	fmt.Fprintln(out, "BOT\tPLAYED\tWINS\tLOSSES\tDRAWS\tWIN RATE")
+
+	for _, e := range entrants {
+		fmt.Fprintf(
+			out,
+			"%s\t%d\t%d\t%d\t%d\t%.1f%%\n",
+			e.Name,
+			e.Played,
+			e.Wins,
+			e.Losses,
+			e.Draws,
+			e.WinRate*100, //nolint:mnd // Percentage.
+		)
+	}
+
+	err := out.Flush()
+	if err != nil {
+		return fmt.Errorf("could not write report: %w", err)
+	}
+
+	return nil
+}
+
+func main() {
+	opts := tournamentOptions{address: "", bots: "", mode: "", format: "", rounds: 0, tls: false}
+
+	flag.StringVar(&opts.address, "addr", defaultAddress, "Address of the game server")
+	flag.BoolVar(&opts.tls, "tls", false, "Connect to the game server over TLS")
+	flag.StringVar(&opts.bots, "bots", "Easy,Medium,Hard", "Difficulties of the entrants")
+	flag.StringVar(&opts.mode, "mode", pb.GameMode_Turns.String(), "Game mode of the matches")
+	flag.IntVar(&opts.rounds, "rounds", defaultRounds, "Number of times every pairing is played")
+	flag.StringVar(&opts.format, "format", formatText, "Report format, one of: text, json")
+	flag.Parse()
+
+	entrants, err := run(opts)
+	if err != nil {
+		log.Fatalf("Tournament failed: %v", err)
+	}
+
+	err = report(entrants, opts.format)
+	if err != nil {
+		log.Fatalf("Tournament failed: %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	defaultAddress         = "localhost:50051"
+	treeGenDepth           = 4
+	treeGenWidth           = 4
+	treeGenVisibilityDepth = 8
+)
+
+var errInvalidFlag = errors.New("invalid flag")
+
+type tuiOptions struct {
+	address   string
+	clientID  string
+	roomID    string
+	missionID string
+	progress  string
+	roomType  string
+	mode      string
+	root      string
+	treeOpts  game.TreeGenOptions
+	tls       bool
+}
+
+func parseFlags() tuiOptions {
+	opts := tuiOptions{
+		address:   "",
+		clientID:  "",
+		roomID:    "",
+		missionID: "",
+		progress:  "",
+		roomType:  "",
+		mode:      "",
+		root:      "",
+		treeOpts: game.TreeGenOptions{
+			Ignore:          game.DefaultTreeGenIgnores[:],
+			VisibilityDepth: treeGenVisibilityDepth,
+			Depth:           0,
+			Width:           0,
+		},
+		tls: false,
+	}
+
+	flag.StringVar(&opts.address, "addr", defaultAddress, "Address of the game server")
+	flag.BoolVar(&opts.tls, "tls", false, "Connect to the game server over TLS")
+	flag.StringVar(&opts.clientID, "client-id", "", "Client id to act as")
+	flag.StringVar(&opts.roomID, "room", "", "Room to join. A new room is created when empty")
+	flag.StringVar(&opts.missionID, "mission", "", "Campaign mission to play instead of a room")
+	flag.StringVar(&opts.progress, "progress", "", "File to save campaign progress to")
+	flag.StringVar(&opts.roomType, "type", pb.RoomType_Regular.String(), "Type of the created room")
+	flag.StringVar(&opts.mode, "mode", pb.GameMode_Turns.String(), "Game mode of the created room")
+	flag.StringVar(&opts.root, "root", ".", "Directory to generate the published tree from")
+	flag.IntVar(&opts.treeOpts.Depth, "depth", treeGenDepth, "Maximum depth of the published tree")
+	flag.IntVar(&opts.treeOpts.Width, "width", treeGenWidth, "Maximum children per tree node")
+	flag.Parse()
+
+	return opts
+}
+
+func run(opts tuiOptions) error {
+	roomType, ok := pb.RoomType_value[opts.roomType]
+	if !ok {
+		return fmt.Errorf("%w: room type %s", errInvalidFlag, opts.roomType)
+	}
+
+	mode, ok := pb.GameMode_value[opts.mode]
+	if !ok {
+		return fmt.Errorf("%w: game mode %s", errInvalidFlag, opts.mode)
+	}
+
+	if opts.progress == "" {
+		path, err := client.DefaultProgressPath()
+		if err != nil {
+			return err
+		}
+
+		opts.progress = path
+	}
+
+	conn, err := client.Dial(opts.address, opts.tls)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx := client.NewContext()
+	if opts.clientID != "" {
+		ctx = client.NewContextWithClientID(opts.clientID)
+	}
+
+	m := &model{
+		ctx:        ctx,
+		roomClient: pb.NewRoomServiceClient(conn),
+		gameClient: pb.NewGameServiceClient(conn),
+		board:      nil,
+		mission:    nil,
+		collapsed:  map[string]map[int32]bool{},
+		cursors:    map[string]int{},
+		marks:      map[string][]int32{},
+		treeRoot:   opts.root,
+		clientID:   client.UnwrapContext(ctx).ClientID,
+		gameID:     "",
+		status:     "Connecting…",
+		room: roomOptions{
+			roomID:       opts.roomID,
+			missionID:    opts.missionID,
+			progressPath: opts.progress,
+			roomType:     pb.RoomType(roomType),
+			mode:         pb.GameMode(mode),
+		},
+		feed:      []string{},
+		panes:     []string{},
+		treeOpts:  opts.treeOpts,
+		focus:     0,
+		offer:     0,
+		width:     0,
+		height:    0,
+		roomState: pb.RoomState_AwaitingPlayers,
+		action:    pb.ActionType_Strike,
+		faction:   pb.Faction_Rogue,
+		ready:     false,
+	}
+
+	streamCtx, cancel := client.NewStreamContext(ctx)
+	defer cancel()
+
+	// Streams are opened before entering the room, as the server only delivers messages to subscribed clients.
+	roomStream, err := m.roomClient.SubscribeMessages(streamCtx, &pb.SubscribeMessagesRequest{})
+	if err != nil {
+		return fmt.Errorf("could not subscribe to room messages: %w", err)
+	}
+
+	gameStream, err := m.gameClient.SubscribeGameEvents(streamCtx, &pb.SubscribeGameEventsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not subscribe to game events: %w", err)
+	}
+
+	program := tea.NewProgram(m, tea.WithAltScreen())
+
+	go pumpRoomMessages(program, roomStream)
+	go pumpGameEvents(program, gameStream)
+
+	_, err = program.Run()
+	if err != nil {
+		return fmt.Errorf("terminal UI stopped: %w", err)
+	}
+
+	return nil
+}
+
+func pumpRoomMessages(program *tea.Program, stream pb.RoomService_SubscribeMessagesClient) {
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			program.Send(statusMsg{text: fmt.Sprintf("Room message stream stopped: %v", err)})
+			return
+		}
+
+		program.Send(roomStateMsg{state: msg.GetType()})
+	}
+}
+
+func pumpGameEvents(program *tea.Program, stream pb.GameService_SubscribeGameEventsClient) {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+
+		if err != nil {
+			program.Send(statusMsg{text: fmt.Sprintf("Game event stream stopped: %v", err)})
+			return
+		}
+
+		program.Send(gameEventMsg{event: event})
+	}
+}
+
+func main() {
+	err := run(parseFlags())
+	if err != nil {
+		log.Fatalf("Terminal UI failed: %v", err)
+	}
+}
@@ -0,0 +1,524 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/passeriform/internal/client"
+	"github.com/passeriform/internal/game"
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	maxFeedLength = 200
+)
+
+type (
+	roomStateMsg struct {
+		state pb.RoomState
+	}
+
+	roomJoinedMsg struct {
+		mission *pb.Mission
+		roomID  string
+	}
+
+	gameEventMsg struct {
+		event *pb.GameEvent
+	}
+
+	boardMsg struct {
+		board *pb.Board
+	}
+
+	statusMsg struct {
+		text string
+	}
+
+	roomOptions struct {
+		roomID       string
+		missionID    string
+		progressPath string
+		roomType     pb.RoomType
+		mode         pb.GameMode
+	}
+
+	model struct {
+		//nolint:containedctx // Carrying the client context for requests issued by commands.
+		ctx        context.Context
+		roomClient pb.RoomServiceClient
+		gameClient pb.GameServiceClient
+		board      *pb.Board
+		mission    *pb.Mission
+		collapsed  map[string]map[int32]bool
+		cursors    map[string]int
+		marks      map[string][]int32
+		treeRoot   string
+		clientID   string
+		gameID     string
+		status     string
+		room       roomOptions
+		feed       []string
+		panes      []string
+		treeOpts   game.TreeGenOptions
+		focus      int
+		offer      int
+		width      int
+		height     int
+		roomState  pb.RoomState
+		action     pb.ActionType
+		faction    pb.Faction
+		ready      bool
+	}
+)
+
+func (m *model) Init() tea.Cmd {
+	return m.enterRoom
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+	case roomJoinedMsg:
+		m.room.roomID = msg.roomID
+		m.mission = msg.mission
+		m.status = "Joined room " + msg.roomID + ". Press r when ready."
+
+		if msg.mission != nil {
+			m.faction = msg.mission.GetFaction()
+			m.status = fmt.Sprintf(
+				"%s: %s Press r when ready.",
+				msg.mission.GetTitle(),
+				msg.mission.GetBriefing(),
+			)
+		}
+	case roomStateMsg:
+		m.roomState = msg.state
+
+		if msg.state == pb.RoomState_InGame {
+			return m, m.publishTree
+		}
+	case gameEventMsg:
+		m.recordEvent(msg.event)
+
+		if msg.event.GetGameOver().GetWinner() == m.clientID && m.mission != nil {
+			return m, tea.Batch(m.fetchBoard, m.completeMission)
+		}
+
+		return m, m.fetchBoard
+	case boardMsg:
+		m.setBoard(msg.board)
+	case statusMsg:
+		m.status = msg.text
+	}
+
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "r":
+		return m.updateReady(!m.ready)
+	case "f":
+		return m.cycleFaction()
+	case "o":
+		m.offer++
+	case "b":
+		return m.purchaseSelected()
+	case "tab":
+		m.focus = (m.focus + 1) % max(len(m.panes), 1)
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "left", "h":
+		m.setCollapsed(true)
+	case "right", "l":
+		m.setCollapsed(false)
+	case "m":
+		m.toggleMark()
+	case "a":
+		m.cycleAction()
+	case "e":
+		return m.submit(&pb.Action{
+			Type:         pb.ActionType_EndTurn,
+			TargetPlayer: "",
+			TargetNode:   0,
+			TargetNodes:  nil,
+			Disguise:     nil,
+			OwnNode:      0,
+			FramedPlayer: "",
+			TargetPath:   nil,
+		})
+	case "enter":
+		return m.submitSelected()
+	}
+
+	return nil
+}
+
+func (m *model) recordEvent(event *pb.GameEvent) {
+	if started := event.GetGameStarted(); started != nil {
+		m.gameID = started.GetGameId()
+	}
+
+	m.feed = append(m.feed, describeEvent(event))
+
+	if len(m.feed) > maxFeedLength {
+		m.feed = m.feed[len(m.feed)-maxFeedLength:]
+	}
+}
+
+func (m *model) setBoard(board *pb.Board) {
+	m.board = board
+	m.panes = append([]string{m.clientID}, slices.Sorted(maps.Keys(board.GetOpponents()))...)
+	m.focus = min(m.focus, len(m.panes)-1)
+}
+
+// paneTree returns the tree shown in the pane of the player, which is redacted for opponents.
+func (m *model) paneTree(playerID string) *pb.FsTree {
+	if playerID == m.clientID {
+		return m.board.GetOwn()
+	}
+
+	return m.board.GetOpponents()[playerID]
+}
+
+func (m *model) focusedRows() (string, []treeRow) {
+	if len(m.panes) == 0 {
+		return "", nil
+	}
+
+	playerID := m.panes[m.focus]
+
+	return playerID, flattenTree(m.paneTree(playerID).GetTop(), m.collapsedOf(playerID))
+}
+
+func (m *model) collapsedOf(playerID string) map[int32]bool {
+	if m.collapsed[playerID] == nil {
+		m.collapsed[playerID] = map[int32]bool{}
+	}
+
+	return m.collapsed[playerID]
+}
+
+func (m *model) moveCursor(delta int) {
+	playerID, rows := m.focusedRows()
+	if len(rows) == 0 {
+		return
+	}
+
+	m.cursors[playerID] = min(max(m.cursors[playerID]+delta, 0), len(rows)-1)
+}
+
+func (m *model) selectedNode() (string, *pb.FsTreeNode) {
+	playerID, rows := m.focusedRows()
+	if len(rows) == 0 {
+		return playerID, nil
+	}
+
+	return playerID, rows[min(m.cursors[playerID], len(rows)-1)].node
+}
+
+func (m *model) setCollapsed(collapsed bool) {
+	playerID, node := m.selectedNode()
+	if node == nil || len(node.GetChildren()) == 0 {
+		return
+	}
+
+	m.collapsedOf(playerID)[node.GetId()] = collapsed
+}
+
+func (m *model) cycleAction() {
+	values := slices.Sorted(maps.Keys(pb.ActionType_name))
+
+	next := (slices.Index(values, int32(m.action)) + 1) % len(values)
+	if pb.ActionType(values[next]) == pb.ActionType_EndTurn {
+		next = (next + 1) % len(values)
+	}
+
+	m.action = pb.ActionType(values[next])
+}
+
+// selectedOffer returns the offer picked from the catalog of the board, if any.
+func (m *model) selectedOffer() *pb.Offer {
+	offers := m.board.GetOffers()
+	if len(offers) == 0 {
+		return nil
+	}
+
+	return offers[m.offer%len(offers)]
+}
+
+// purchaseSelected buys the picked offer. Boosts are applied to the node selected in the own pane.
+func (m *model) purchaseSelected() tea.Cmd {
+	offer := m.selectedOffer()
+	if offer == nil {
+		return nil
+	}
+
+	purchase := &pb.Purchase{
+		Upgrade: offer.GetUpgrade(),
+		Market:  offer.GetMarket(),
+		Node:    m.ownSelection(),
+	}
+
+	return func() tea.Msg {
+		unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+		defer cancel()
+
+		resp, err := m.gameClient.Purchase(unaryCtx, &pb.PurchaseRequest{Purchase: purchase})
+		if err != nil {
+			return statusMsg{text: fmt.Sprintf("Could not purchase upgrade: %v", err)}
+		}
+
+		return statusMsg{text: purchase.GetUpgrade().String() + ": " + resp.GetStatus().String()}
+	}
+}
+
+// toggleMark marks the selected node for actions aimed at several nodes, or unmarks it.
+func (m *model) toggleMark() {
+	playerID, node := m.selectedNode()
+	if node == nil {
+		return
+	}
+
+	marks := m.marks[playerID]
+
+	if idx := slices.Index(marks, node.GetId()); idx >= 0 {
+		m.marks[playerID] = slices.Delete(marks, idx, idx+1)
+		return
+	}
+
+	m.marks[playerID] = append(marks, node.GetId())
+}
+
+// submitSelected uses the action on the selected node, along with the marked nodes of the pane if there are any. The
+// node under the cursor of the own pane is the own node of the action, and the first other opponent is framed. The
+// target path is made of the labels known along the way to the selected node.
+func (m *model) submitSelected() tea.Cmd {
+	playerID, node := m.selectedNode()
+	if node == nil {
+		return nil
+	}
+
+	marks := m.marks[playerID]
+	delete(m.marks, playerID)
+
+	if len(marks) > 0 && !slices.Contains(marks, node.GetId()) {
+		marks = append(marks, node.GetId())
+	}
+
+	return m.submit(&pb.Action{
+		Type:         m.action,
+		TargetPlayer: playerID,
+		TargetNode:   node.GetId(),
+		TargetNodes:  marks,
+		Disguise:     nil,
+		OwnNode:      m.ownSelection(),
+		FramedPlayer: m.framedFor(playerID),
+		TargetPath:   m.labelPath(playerID, node.GetId()),
+	})
+}
+
+func (m *model) labelPath(playerID string, id int32) []string {
+	path := m.paneTree(playerID).GetTop().Path(id)
+	labels := make([]string, 0, len(path))
+
+	for _, node := range path[min(1, len(path)):] {
+		labels = append(labels, node.GetLabel())
+	}
+
+	return labels
+}
+
+func (m *model) ownSelection() int32 {
+	rows := flattenTree(m.board.GetOwn().GetTop(), m.collapsedOf(m.clientID))
+	if len(rows) == 0 {
+		return 0
+	}
+
+	return rows[min(m.cursors[m.clientID], len(rows)-1)].node.GetId()
+}
+
+func (m *model) framedFor(targetID string) string {
+	for _, playerID := range m.panes {
+		if playerID != m.clientID && playerID != targetID {
+			return playerID
+		}
+	}
+
+	return ""
+}
+
+func (m *model) enterRoom() tea.Msg {
+	unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+	defer cancel()
+
+	if m.room.missionID != "" {
+		return m.startMission(unaryCtx)
+	}
+
+	if m.room.roomID != "" {
+		resp, err := m.roomClient.JoinRoom(unaryCtx, &pb.JoinRoomRequest{RoomId: m.room.roomID})
+		if err != nil {
+			return statusMsg{text: fmt.Sprintf("Could not join room: %v", err)}
+		}
+
+		if resp.GetStatus() != pb.ResponseStatus_Ok {
+			return statusMsg{text: "Could not join room: " + resp.GetStatus().String()}
+		}
+
+		return roomJoinedMsg{mission: nil, roomID: m.room.roomID}
+	}
+
+	resp, err := m.roomClient.CreateRoom(unaryCtx, &pb.CreateRoomRequest{
+		RoomType: m.room.roomType,
+		GameMode: m.room.mode,
+	})
+	if err != nil {
+		return statusMsg{text: fmt.Sprintf("Could not create room: %v", err)}
+	}
+
+	return roomJoinedMsg{mission: nil, roomID: resp.GetRoomId()}
+}
+
+// startMission creates the room of the mission, which must have been unlocked by completing the earlier missions of
+// the campaign.
+func (m *model) startMission(ctx context.Context) tea.Msg {
+	missionID := m.room.missionID
+
+	missions, err := m.roomClient.ListMissions(ctx, &pb.ListMissionsRequest{})
+	if err != nil {
+		return statusMsg{text: fmt.Sprintf("Could not list missions: %v", err)}
+	}
+
+	progress, err := client.LoadProgress(m.room.progressPath)
+	if err != nil {
+		return statusMsg{text: err.Error()}
+	}
+
+	if !client.Unlocked(missions.GetCampaign(), progress, missionID) {
+		return statusMsg{text: "Could not start mission: " + client.ErrMissionLocked.Error()}
+	}
+
+	resp, err := m.roomClient.StartMission(ctx, &pb.StartMissionRequest{MissionId: missionID})
+	if err != nil {
+		return statusMsg{text: fmt.Sprintf("Could not start mission: %v", err)}
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return statusMsg{text: "Could not start mission: " + resp.GetStatus().String()}
+	}
+
+	return roomJoinedMsg{
+		mission: client.FindMission(missions.GetCampaign(), missionID),
+		roomID:  resp.GetRoomId(),
+	}
+}
+
+// completeMission saves the mission as completed in the campaign progress, once the server confirms it was won.
+func (m *model) completeMission() tea.Msg {
+	err := client.CompleteMission(m.ctx, m.gameClient, m.room.progressPath, m.mission, m.gameID)
+	if err != nil {
+		return statusMsg{text: err.Error()}
+	}
+
+	return statusMsg{text: "Mission " + m.mission.GetTitle() + " completed"}
+}
+
+func (m *model) updateReady(ready bool) tea.Cmd {
+	m.ready = ready
+
+	return func() tea.Msg {
+		unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+		defer cancel()
+
+		resp, err := m.roomClient.UpdateReady(unaryCtx, &pb.UpdateReadyRequest{Ready: ready})
+		if err != nil {
+			return statusMsg{text: fmt.Sprintf("Could not update ready state: %v", err)}
+		}
+
+		return statusMsg{text: fmt.Sprintf("Ready: %t (%s)", ready, resp.GetStatus().String())}
+	}
+}
+
+// cycleFaction selects the next faction for the next game of the room.
+func (m *model) cycleFaction() tea.Cmd {
+	if m.roomState == pb.RoomState_InGame {
+		return nil
+	}
+
+	m.faction = pb.Faction((int32(m.faction) + 1) % int32(len(pb.Faction_name)))
+	faction := m.faction
+
+	return func() tea.Msg {
+		unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+		defer cancel()
+
+		resp, err := m.roomClient.SelectFaction(
+			unaryCtx,
+			&pb.SelectFactionRequest{Faction: faction},
+		)
+		if err != nil {
+			return statusMsg{text: fmt.Sprintf("Could not select faction: %v", err)}
+		}
+
+		return statusMsg{text: fmt.Sprintf("Faction: %s (%s)", faction, resp.GetStatus().String())}
+	}
+}
+
+func (m *model) publishTree() tea.Msg {
+	tree := game.NewFsTree(m.treeRoot, m.treeOpts)
+
+	unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+	defer cancel()
+
+	resp, err := m.gameClient.AddPlayer(unaryCtx, &pb.AddPlayerRequest{Tree: &tree})
+	if err != nil {
+		return statusMsg{text: fmt.Sprintf("Could not publish tree: %v", err)}
+	}
+
+	return statusMsg{
+		text: fmt.Sprintf("Published tree of %s (%s)", m.treeRoot, resp.GetStatus().String()),
+	}
+}
+
+func (m *model) fetchBoard() tea.Msg {
+	unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+	defer cancel()
+
+	resp, err := m.gameClient.GetBoard(unaryCtx, &pb.GetBoardRequest{})
+	if err != nil {
+		return statusMsg{text: fmt.Sprintf("Could not fetch board: %v", err)}
+	}
+
+	if resp.GetStatus() != pb.ResponseStatus_Ok {
+		return statusMsg{text: "Board unavailable: " + resp.GetStatus().String()}
+	}
+
+	return boardMsg{board: resp.GetBoard()}
+}
+
+func (m *model) submit(action *pb.Action) tea.Cmd {
+	return func() tea.Msg {
+		unaryCtx, cancel := client.NewUnaryContext(m.ctx)
+		defer cancel()
+
+		resp, err := m.gameClient.SubmitAction(unaryCtx, &pb.SubmitActionRequest{Action: action})
+		if err != nil {
+			return statusMsg{text: fmt.Sprintf("Could not submit action: %v", err)}
+		}
+
+		return statusMsg{text: action.GetType().String() + ": " + resp.GetStatus().String()}
+	}
+}
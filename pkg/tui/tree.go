@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	indentWidth = 2
+)
+
+type treeRow struct {
+	node      *pb.FsTreeNode
+	depth     int
+	collapsed bool
+}
+
+// flattenTree lists the rows of the tree in display order, skipping the descendants of collapsed nodes.
+func flattenTree(top *pb.FsTreeNode, collapsed map[int32]bool) []treeRow {
+	rows := []treeRow{}
+
+	var visit func(node *pb.FsTreeNode, depth int)
+
+	visit = func(node *pb.FsTreeNode, depth int) {
+		rows = append(rows, treeRow{node: node, depth: depth, collapsed: collapsed[node.GetId()]})
+
+		if collapsed[node.GetId()] {
+			return
+		}
+
+		for _, child := range node.GetChildren() {
+			visit(child, depth+1)
+		}
+	}
+
+	if top != nil {
+		visit(top, 0)
+	}
+
+	return rows
+}
+
+func (row treeRow) marker() string {
+	switch {
+	case len(row.node.GetChildren()) == 0:
+		return "•"
+	case row.collapsed:
+		return "▸"
+	default:
+		return "▾"
+	}
+}
+
+// render formats the row with the ratings of the node. Ratings hidden by the fog of war are shown as unknown.
+func (row treeRow) render(revealed bool) string {
+	node := row.node
+
+	label := node.GetLabel()
+	if label == "" {
+		label = "???"
+	}
+
+	ratings := "P? S? R?"
+	if revealed || node.GetVisibility() >= pb.Visibility_Probed {
+		ratings = fmt.Sprintf(
+			"P%d S%d/%d R%d",
+			node.GetPower(),
+			node.GetShield(),
+			node.GetMaxShield(),
+			node.GetRechargeRate(),
+		)
+	}
+
+	flags := ""
+
+	if node.GetSentinel() {
+		flags += " ◆"
+	}
+
+	if node.GetUpkeep() {
+		flags += " ⟳"
+	}
+
+	if node.GetDestroyed() {
+		flags += " ✖"
+	}
+
+	for _, effect := range node.GetEffects() {
+		flags += fmt.Sprintf(" %s×%d", effect.GetType().String(), effect.GetStacks())
+
+		if effect.GetRemaining() > 0 {
+			flags += fmt.Sprintf(" (%d)", effect.GetRemaining())
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s%s %s [%d] %s %s%s",
+		strings.Repeat(" ", row.depth*indentWidth),
+		row.marker(),
+		label,
+		node.GetId(),
+		ratings,
+		node.GetVisibility().String(),
+		flags,
+	)
+}
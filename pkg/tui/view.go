@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/passeriform/internal/pb"
+)
+
+const (
+	feedHeight    = 6
+	chromeHeight  = 6
+	paneChrome    = 4
+	minPaneHeight = 3
+	helpLine      = "tab pane · ↑↓ move · ←→ fold · m mark · a action · enter act · " +
+		"o offer · b buy · e end · r ready · f faction · q quit"
+)
+
+//nolint:gochecknoglobals // Styles are static.
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	dimStyle     = lipgloss.NewStyle().Faint(true)
+	cursorStyle  = lipgloss.NewStyle().Reverse(true)
+	markedStyle  = lipgloss.NewStyle().Underline(true)
+	paneStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	focusedStyle = paneStyle.BorderForeground(lipgloss.Color("12"))
+)
+
+func (m *model) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.header(),
+		m.boardView(),
+		m.feedView(),
+		dimStyle.Render(m.status),
+		dimStyle.Render(helpLine),
+	)
+}
+
+func (m *model) header() string {
+	room := m.room.roomID
+	if room == "" {
+		room = "-"
+	}
+
+	line := fmt.Sprintf("Client %s · Room %s (%s)", m.clientID, room, m.roomState.String())
+
+	if m.board != nil {
+		line += fmt.Sprintf(
+			" · Game %s · %s turn %d · current %s · budget %d · action %s",
+			m.gameID,
+			m.board.GetMode().String(),
+			m.board.GetTurn(),
+			m.board.GetCurrentPlayer(),
+			m.board.GetBudget(),
+			m.action.String(),
+		)
+	}
+
+	if m.mission != nil {
+		line += fmt.Sprintf(
+			" · mission %s: %s %d",
+			m.mission.GetTitle(),
+			m.mission.GetObjective().GetType().String(),
+			m.mission.GetObjective().GetCount(),
+		)
+	}
+
+	if offer := m.selectedOffer(); offer != nil {
+		line += fmt.Sprintf(
+			" · offer %s on %s for %d",
+			offer.GetUpgrade().String(),
+			offer.GetMarket().String(),
+			offer.GetPrice(),
+		)
+	}
+
+	return titleStyle.Render(line)
+}
+
+func (m *model) boardView() string {
+	if m.board == nil {
+		return dimStyle.Render("Waiting for the game to start…")
+	}
+
+	paneWidth := 0
+	if len(m.panes) > 0 && m.width > 0 {
+		paneWidth = max(m.width/len(m.panes)-paneChrome, 0)
+	}
+
+	paneHeight := max(m.height-feedHeight-chromeHeight-paneChrome, minPaneHeight)
+
+	panes := make([]string, 0, len(m.panes))
+
+	for idx, playerID := range m.panes {
+		style := paneStyle
+		if idx == m.focus {
+			style = focusedStyle
+		}
+
+		panes = append(panes, style.Width(paneWidth).Render(m.paneView(playerID, paneHeight)))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+}
+
+// paneView renders the tree of the player, scrolled so that the cursor stays within the pane height.
+func (m *model) paneView(playerID string, height int) string {
+	title := playerID
+	if playerID == m.clientID {
+		title += " (you)"
+	}
+
+	if faction, ok := m.board.GetFactions()[playerID]; ok {
+		title += " · " + faction.String()
+	}
+
+	rows := flattenTree(m.paneTree(playerID).GetTop(), m.collapsedOf(playerID))
+	cursor := min(m.cursors[playerID], max(len(rows)-1, 0))
+	offset := max(cursor-height+1, 0)
+
+	lines := []string{titleStyle.Render(title)}
+
+	for idx := offset; idx < min(offset+height, len(rows)); idx++ {
+		line := rows[idx].render(playerID == m.clientID)
+
+		if slices.Contains(m.marks[playerID], rows[idx].node.GetId()) {
+			line = markedStyle.Render(line)
+		}
+
+		if idx == cursor && m.panes[m.focus] == playerID {
+			line = cursorStyle.Render(line)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *model) feedView() string {
+	start := max(len(m.feed)-feedHeight, 0)
+
+	return strings.Join(m.feed[start:], "\n")
+}
+
+// describeEvent summarizes a game event in a single line of the event feed.
+func describeEvent(event *pb.GameEvent) string {
+	prefix := fmt.Sprintf("#%d t%d ", event.GetSequence(), event.GetTurn())
+
+	switch payload := event.GetPayload().(type) {
+	case *pb.GameEvent_GameStarted:
+		return prefix + fmt.Sprintf(
+			"Game %s started (%s) with %s",
+			payload.GameStarted.GetGameId(),
+			payload.GameStarted.GetMode().String(),
+			strings.Join(payload.GameStarted.GetPlayers(), ", "),
+		)
+	case *pb.GameEvent_TurnStarted:
+		return prefix + fmt.Sprintf(
+			"Turn of %s with budget %d",
+			payload.TurnStarted.GetPlayer(),
+			payload.TurnStarted.GetBudget(),
+		)
+	case *pb.GameEvent_ActionResolved:
+		return prefix + describeAction(payload.ActionResolved)
+	case *pb.GameEvent_PlayerEliminated:
+		return prefix + payload.PlayerEliminated.GetPlayer() + " was eliminated"
+	case *pb.GameEvent_ScanResult:
+		return prefix + fmt.Sprintf(
+			"Scan of %s revealed %d nodes",
+			payload.ScanResult.GetTargetPlayer(),
+			len(payload.ScanResult.GetNodes()),
+		)
+	case *pb.GameEvent_Alert:
+		return prefix + "Alert: " + payload.Alert.GetEffect().String() + " somewhere in your tree"
+	case *pb.GameEvent_ScanStep:
+		return prefix + fmt.Sprintf(
+			"Deep scan of %s step %d: [%d] → [%d]",
+			payload.ScanStep.GetTargetPlayer(),
+			payload.ScanStep.GetStep(),
+			payload.ScanStep.GetFrom(),
+			payload.ScanStep.GetNode().GetId(),
+		)
+	case *pb.GameEvent_Prompt:
+		return prefix + describePrompt(payload.Prompt)
+	case *pb.GameEvent_Purchased:
+		return prefix + describePurchase(payload.Purchased)
+	case *pb.GameEvent_GameOver:
+		if payload.GameOver.GetWinner() == "" {
+			return prefix + "Game over: draw"
+		}
+
+		return prefix + "Game over: " + payload.GameOver.GetWinner() + " wins"
+	default:
+		return prefix + "Unknown event"
+	}
+}
+
+func describeAction(resolved *pb.ActionResolved) string {
+	action := resolved.GetAction()
+	line := fmt.Sprintf("%s used %s", resolved.GetPlayer(), action.GetType().String())
+
+	if action.GetTargetPlayer() != "" {
+		line += fmt.Sprintf(" on %s [%d]", action.GetTargetPlayer(), action.GetTargetNode())
+	}
+
+	for _, change := range resolved.GetChanges() {
+		line += fmt.Sprintf(
+			" · %s [%d] S%+d P%+d",
+			change.GetOwner(),
+			change.GetNode(),
+			change.GetShieldDelta(),
+			change.GetPowerDelta(),
+		)
+
+		if change.GetDestroyed() {
+			line += " destroyed"
+		}
+	}
+
+	return line
+}
+
+func describePurchase(purchased *pb.Purchased) string {
+	purchase := purchased.GetPurchase()
+	line := fmt.Sprintf(
+		"%s bought %s on %s",
+		purchased.GetPlayer(),
+		purchase.GetUpgrade().String(),
+		purchase.GetMarket().String(),
+	)
+
+	if purchased.GetDetected() {
+		line += " and got caught"
+	}
+
+	return line
+}
+
+func describePrompt(prompt *pb.Prompt) string {
+	switch prompt.GetType() {
+	case pb.PromptType_BackdoorInstalled:
+		return fmt.Sprintf(
+			"A backdoor was installed into %s %v",
+			prompt.GetOwner(),
+			prompt.GetNodes(),
+		)
+	case pb.PromptType_BackdoorDetected:
+		return fmt.Sprintf("A backdoor was installed. Infected area %v", prompt.GetNodes())
+	default:
+		return "Unknown prompt"
+	}
+}